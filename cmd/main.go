@@ -1,6 +1,9 @@
 package main
 
-import serv "pull-request-reviewers-service/server"
+import (
+	serv "pull-request-reviewers-service/server"
+	_ "time/tzdata"
+)
 
 func main() {
 	server := serv.Server{}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"pull-request-reviewers-service/internal/models"
+	"pull-request-reviewers-service/internal/service"
+)
+
+type AbsenceHandler struct {
+	s *service.AbsenceService
+}
+
+func NewAbsenceHandler(s *service.AbsenceService) *AbsenceHandler {
+	return &AbsenceHandler{s: s}
+}
+
+func (h *AbsenceHandler) CreateAbsence(w http.ResponseWriter, r *http.Request) {
+	var absence models.Absence
+	err := json.NewDecoder(r.Body).Decode(&absence)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON")
+		return
+	}
+
+	absence, err = h.s.CreateAbsence(r.Context(), absence)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidAbsencePeriod) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "absence must end after it starts")
+			return
+		}
+		if errors.Is(err, models.ErrUserNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	absenceResp := models.AbsenceResponse{Absence: absence}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(absenceResp)
+}
+
+func (h *AbsenceHandler) GetAbsences(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	absences, err := h.s.GetAbsences(r.Context(), userID)
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	absencesResp := models.AbsencesResponse{
+		UserID:   userID,
+		Absences: absences,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(absencesResp)
+}
+
+func (h *AbsenceHandler) DeleteAbsence(w http.ResponseWriter, r *http.Request) {
+	var reqBody struct {
+		AbsenceID int64 `json:"absence_id"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&reqBody)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON")
+		return
+	}
+
+	err = h.s.DeleteAbsence(r.Context(), reqBody.AbsenceID)
+	if err != nil {
+		if errors.Is(err, models.ErrAbsenceNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "absence not found")
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
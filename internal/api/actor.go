@@ -0,0 +1,13 @@
+package api
+
+import (
+	"net/http"
+	"pull-request-reviewers-service/internal/service"
+)
+
+func ActorMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := service.WithActor(r.Context(), r.Header.Get("X-Actor-ID"))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
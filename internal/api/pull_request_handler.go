@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -33,6 +34,14 @@ func (h *PullRequestHandler) CreatePullRequest(w http.ResponseWriter, r *http.Re
 			writeHTTPError(w, http.StatusConflict, "PR_EXISTS", "pull request already exists")
 			return
 		}
+		if errors.Is(err, models.ErrInvalidReviewersCount) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "reviewers_count must be positive")
+			return
+		}
+		if errors.Is(err, models.ErrNotEnoughMembersInTeam) {
+			writeHTTPError(w, http.StatusConflict, "NO_CANDIDATE", "not enough active reviewer candidates in team")
+			return
+		}
 
 		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
 		return
@@ -45,9 +54,42 @@ func (h *PullRequestHandler) CreatePullRequest(w http.ResponseWriter, r *http.Re
 	_ = json.NewEncoder(w).Encode(prResp)
 }
 
+func (h *PullRequestHandler) PreviewAssignment(w http.ResponseWriter, r *http.Request) {
+	var prShort models.PullRequestShort
+	err := json.NewDecoder(r.Body).Decode(&prShort)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON")
+		return
+	}
+
+	preview, err := h.s.PreviewAssignment(r.Context(), prShort)
+	if err != nil {
+		if errors.Is(err, models.ErrAuthorNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "author not found")
+			return
+		}
+		if errors.Is(err, models.ErrInvalidReviewersCount) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "reviewers_count must be positive")
+			return
+		}
+		if errors.Is(err, models.ErrNotEnoughMembersInTeam) {
+			writeHTTPError(w, http.StatusConflict, "NO_CANDIDATE", "not enough active reviewer candidates in team")
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	previewResp := models.AssignmentPreviewResponse{Preview: preview}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(previewResp)
+}
+
 func (h *PullRequestHandler) MergePullRequest(w http.ResponseWriter, r *http.Request) {
 	var prID struct {
 		PullRequestID string `json:"pull_request_id"`
+		Force         bool   `json:"force"`
 	}
 	err := json.NewDecoder(r.Body).Decode(&prID)
 	if err != nil {
@@ -55,12 +97,28 @@ func (h *PullRequestHandler) MergePullRequest(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	pullRequest, err := h.s.MergePullRequest(r.Context(), prID.PullRequestID)
+	pullRequest, err := h.s.MergePullRequest(r.Context(), prID.PullRequestID, prID.Force)
 	if err != nil {
 		if errors.Is(err, models.ErrPullRequestNotFound) {
 			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "pull request not found")
 			return
 		}
+		if errors.Is(err, models.ErrNotEnoughApprovals) {
+			writeHTTPError(w, http.StatusConflict, "NOT_APPROVED", err.Error())
+			return
+		}
+		if errors.Is(err, models.ErrActorRequired) {
+			writeHTTPError(w, http.StatusUnauthorized, "UNAUTHORIZED", err.Error())
+			return
+		}
+		if errors.Is(err, models.ErrForceMergeForbidden) {
+			writeHTTPError(w, http.StatusForbidden, "FORBIDDEN", "only the team lead can force a merge")
+			return
+		}
+		if errors.Is(err, models.ErrInvalidStatusTransition) {
+			writeHTTPError(w, http.StatusConflict, "INVALID_TRANSITION", "only open pull requests can be merged")
+			return
+		}
 		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
 		return
 	}
@@ -70,6 +128,93 @@ func (h *PullRequestHandler) MergePullRequest(w http.ResponseWriter, r *http.Req
 	_ = json.NewEncoder(w).Encode(pullRequest)
 }
 
+func (h *PullRequestHandler) MarkReady(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.s.MarkReady)
+}
+
+func (h *PullRequestHandler) ClosePullRequest(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.s.ClosePullRequest)
+}
+
+func (h *PullRequestHandler) ReopenPullRequest(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.s.ReopenPullRequest)
+}
+
+func (h *PullRequestHandler) changeStatus(w http.ResponseWriter, r *http.Request,
+	transition func(ctx context.Context, prID string) (models.PullRequest, error)) {
+	var prID struct {
+		PullRequestID string `json:"pull_request_id"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&prID)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON")
+		return
+	}
+
+	pullRequest, err := transition(r.Context(), prID.PullRequestID)
+	if err != nil {
+		if errors.Is(err, models.ErrPullRequestNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "pull request not found")
+			return
+		}
+		if errors.Is(err, models.ErrInvalidStatusTransition) {
+			writeHTTPError(w, http.StatusConflict, "INVALID_TRANSITION", err.Error())
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	prResp := models.PullRequestResponse{PullRequest: pullRequest}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(prResp)
+}
+
+func (h *PullRequestHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	var review struct {
+		PullRequestID string `json:"pull_request_id"`
+		ReviewerID    string `json:"reviewer_id"`
+		State         string `json:"state"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&review)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON")
+		return
+	}
+
+	pullRequest, err := h.s.SubmitReview(r.Context(), review.PullRequestID, review.ReviewerID, review.State)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidReviewState) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "state must be APPROVED, CHANGES_REQUESTED or COMMENTED")
+			return
+		}
+		if errors.Is(err, models.ErrPullRequestNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "pull request not found")
+			return
+		}
+		if errors.Is(err, models.ErrPullRequestAlreadyMerged) {
+			writeHTTPError(w, http.StatusConflict, "PR_MERGED", "cannot review merged PR")
+			return
+		}
+		if errors.Is(err, models.ErrPullRequestNotOpen) {
+			writeHTTPError(w, http.StatusConflict, "PR_NOT_OPEN", "cannot review draft or closed PR")
+			return
+		}
+		if errors.Is(err, models.ErrUserNotReviewer) {
+			writeHTTPError(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	prResp := models.PullRequestResponse{PullRequest: pullRequest}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(prResp)
+}
+
 func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Request) {
 	var reassign struct {
 		PullRequestID string `json:"pull_request_id"`
@@ -95,6 +240,65 @@ func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Req
 			writeHTTPError(w, http.StatusConflict, "PR_MERGED", "cannot reassign on merged PR")
 			return
 		}
+		if errors.Is(err, models.ErrPullRequestNotOpen) {
+			writeHTTPError(w, http.StatusConflict, "PR_NOT_OPEN", "cannot reassign on draft or closed PR")
+			return
+		}
+		if errors.Is(err, models.ErrUserNotReviewer) {
+			writeHTTPError(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
+			return
+		}
+		if errors.Is(err, models.ErrNotEnoughMembersInTeam) {
+			writeHTTPError(w, http.StatusConflict, "NO_CANDIDATE", "no active replacement candidate in team")
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	reassignResp := models.ReassignResponse{
+		PullRequest: pullRequest,
+		ReplacedBy:  newReviewerID,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(reassignResp)
+}
+
+func (h *PullRequestHandler) DeclineReview(w http.ResponseWriter, r *http.Request) {
+	var decline struct {
+		PullRequestID string `json:"pull_request_id"`
+		ReviewerID    string `json:"reviewer_id"`
+		Reason        string `json:"reason"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&decline)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON")
+		return
+	}
+
+	pullRequest, newReviewerID, err := h.s.DeclineReview(r.Context(), decline.PullRequestID, decline.ReviewerID, decline.Reason)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidDeclineReason) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "reason must be CONFLICT_OF_INTEREST, NO_EXPERTISE or OVERLOADED")
+			return
+		}
+		if errors.Is(err, models.ErrPullRequestNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "pull request not found")
+			return
+		}
+		if errors.Is(err, models.ErrUserNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		}
+		if errors.Is(err, models.ErrPullRequestAlreadyMerged) {
+			writeHTTPError(w, http.StatusConflict, "PR_MERGED", "cannot decline review on merged PR")
+			return
+		}
+		if errors.Is(err, models.ErrPullRequestNotOpen) {
+			writeHTTPError(w, http.StatusConflict, "PR_NOT_OPEN", "cannot decline review on draft or closed PR")
+			return
+		}
 		if errors.Is(err, models.ErrUserNotReviewer) {
 			writeHTTPError(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
 			return
@@ -117,8 +321,18 @@ func (h *PullRequestHandler) ReassignReviewer(w http.ResponseWriter, r *http.Req
 }
 
 func (h *PullRequestHandler) GetAssignStat(w http.ResponseWriter, r *http.Request) {
-	stat, err := h.s.GetAssignStat(r.Context())
+	filter, err := parseStatsFilter(r)
 	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	stat, err := h.s.GetAssignStat(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidStatsFilter) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+			return
+		}
 		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", "server error")
 		return
 	}
@@ -127,3 +341,146 @@ func (h *PullRequestHandler) GetAssignStat(w http.ResponseWriter, r *http.Reques
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(stat)
 }
+
+func (h *PullRequestHandler) GetUnderstaffedPullRequests(w http.ResponseWriter, r *http.Request) {
+	pullRequests, err := h.s.GetUnderstaffedPullRequests(r.Context())
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	prsResp := models.PullRequestsResponse{PullRequests: pullRequests}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(prsResp)
+}
+
+func (h *PullRequestHandler) GetAssignmentLog(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	decisions, err := h.s.GetAssignmentLog(r.Context(), prID)
+	if err != nil {
+		if errors.Is(err, models.ErrPullRequestNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "pull request not found")
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	logResp := models.AssignmentLogResponse{
+		PullRequestID: prID,
+		Decisions:     decisions,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(logResp)
+}
+
+func (h *PullRequestHandler) GetOverdueReviews(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	reviews, err := h.s.GetOverdueReviews(r.Context(), teamName)
+	if err != nil {
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	overdueResp := models.OverdueReviewsResponse{Reviews: reviews}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(overdueResp)
+}
+
+func (h *PullRequestHandler) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	prID := r.URL.Query().Get("pull_request_id")
+	events, err := h.s.GetTimeline(r.Context(), prID)
+	if err != nil {
+		if errors.Is(err, models.ErrPullRequestNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "pull request not found")
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	timelineResp := models.TimelineResponse{
+		PullRequestID: prID,
+		Events:        events,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(timelineResp)
+}
+
+func (h *PullRequestHandler) GetReviewMetrics(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseStatsFilter(r)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	metrics, err := h.s.GetReviewMetrics(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidStatsFilter) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(metrics)
+}
+
+func (h *PullRequestHandler) GetFairnessReport(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseStatsFilter(r)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	report, err := h.s.GetFairnessReport(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidStatsFilter) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+			return
+		}
+		if errors.Is(err, models.ErrTeamNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+func (h *PullRequestHandler) GetPairingMatrix(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseStatsFilter(r)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	matrix, err := h.s.GetPairingMatrix(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, models.ErrTeamNameRequired) || errors.Is(err, models.ErrInvalidStatsFilter) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+			return
+		}
+		if errors.Is(err, models.ErrTeamNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(matrix)
+}
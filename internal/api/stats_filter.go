@@ -0,0 +1,47 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"pull-request-reviewers-service/internal/models"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+func parseStatsFilter(r *http.Request) (models.StatsFilter, error) {
+	query := r.URL.Query()
+	filter := models.StatsFilter{
+		TeamName: query.Get("team_name"),
+		Status:   query.Get("status"),
+		Bucket:   query.Get("bucket"),
+	}
+
+	from, err := parseTimeParam(query.Get("from"), false)
+	if err != nil {
+		return models.StatsFilter{}, err
+	}
+	to, err := parseTimeParam(query.Get("to"), true)
+	if err != nil {
+		return models.StatsFilter{}, err
+	}
+	filter.From, filter.To = from, to
+	return filter, nil
+}
+
+func parseTimeParam(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad time %q, expected YYYY-MM-DD or RFC 3339", models.ErrInvalidStatsFilter, value)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"pull-request-reviewers-service/internal/models"
 	"pull-request-reviewers-service/internal/service"
@@ -29,6 +30,14 @@ func (h *TeamHandler) CreateTeam(w http.ResponseWriter, r *http.Request) {
 			writeHTTPError(w, http.StatusBadRequest, "TEAM_EXISTS", "team_name already exists")
 			return
 		}
+		if errors.Is(err, models.ErrInvalidWorkingHours) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+			return
+		}
+		if errors.Is(err, models.ErrInvalidCapacity) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "max_open_reviews must be positive")
+			return
+		}
 		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
 		return
 	}
@@ -58,8 +67,9 @@ func (h *TeamHandler) GetTeam(w http.ResponseWriter, r *http.Request) {
 
 func (h *TeamHandler) SetIsActiveUser(w http.ResponseWriter, r *http.Request) {
 	var reqBody struct {
-		UserID   string `json:"user_id"`
-		IsActive bool   `json:"is_active"`
+		UserID          string `json:"user_id"`
+		IsActive        bool   `json:"is_active"`
+		ReassignReviews *bool  `json:"reassign_reviews"`
 	}
 
 	err := json.NewDecoder(r.Body).Decode(&reqBody)
@@ -68,7 +78,7 @@ func (h *TeamHandler) SetIsActiveUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.s.SetIsActive(r.Context(), reqBody.UserID, reqBody.IsActive)
+	user, reassigned, err := h.s.SetIsActive(r.Context(), reqBody.UserID, reqBody.IsActive, reqBody.ReassignReviews)
 	if err != nil {
 		if errors.Is(err, models.ErrUserNotFound) {
 			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
@@ -78,13 +88,52 @@ func (h *TeamHandler) SetIsActiveUser(w http.ResponseWriter, r *http.Request) {
 		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
 		return
 	}
-	userResp := models.UserResponse{User: user}
+	userResp := models.UserResponse{
+		User:                   user,
+		ReassignedPullRequests: reassigned,
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	_ = json.NewEncoder(w).Encode(userResp)
 }
 
+func (h *TeamHandler) DeactivateUsers(w http.ResponseWriter, r *http.Request) {
+	var reqBody struct {
+		UserIDs  []string `json:"user_ids"`
+		TeamName string   `json:"team_name"`
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&reqBody)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON")
+		return
+	}
+
+	report, err := h.s.DeactivateUsers(r.Context(), reqBody.UserIDs, reqBody.TeamName)
+	if err != nil {
+		if errors.Is(err, models.ErrNothingToDeactivate) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+			return
+		}
+		if errors.Is(err, models.ErrTeamNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		}
+		if errors.Is(err, models.ErrUserNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		}
+
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(report)
+}
+
 func (h *TeamHandler) GetPRsByReviewer(w http.ResponseWriter, r *http.Request) {
 	userID := r.URL.Query().Get("user_id")
 	pullRequests, err := h.s.GetPRsByReviewer(r.Context(), userID)
@@ -102,6 +151,140 @@ func (h *TeamHandler) GetPRsByReviewer(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(usersPRs)
 }
 
+func (h *TeamHandler) GetTeamSettings(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	settings, err := h.s.GetTeamSettings(r.Context(), teamName)
+	if err != nil {
+		if errors.Is(err, models.ErrTeamNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	settingsResp := models.TeamSettingsResponse{Settings: settings}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(settingsResp)
+}
+
+func (h *TeamHandler) UpdateTeamSettings(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON")
+		return
+	}
+	var settings models.TeamSettings
+	if err = json.Unmarshal(body, &settings); err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON")
+		return
+	}
+
+	settings, err = h.s.UpdateTeamSettings(r.Context(), settings.TeamName, func(stored *models.TeamSettings) error {
+		return json.Unmarshal(body, stored)
+	})
+	if err != nil {
+		if errors.Is(err, models.ErrTeamNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		}
+		if errors.Is(err, models.ErrUnknownAssignmentStrategy) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "unknown assignment strategy")
+			return
+		}
+		if errors.Is(err, models.ErrInvalidReviewersCount) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "reviewers_count must be positive")
+			return
+		}
+		if errors.Is(err, models.ErrInvalidRequiredApprovals) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "required_approvals cannot be negative")
+			return
+		}
+		if errors.Is(err, models.ErrApprovalsExceedReviewers) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "required_approvals cannot exceed reviewers_count")
+			return
+		}
+		if errors.Is(err, models.ErrInvalidFallbackTeam) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "team cannot fall back to itself")
+			return
+		}
+		if errors.Is(err, models.ErrInvalidCapacity) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "max_open_reviews must be positive")
+			return
+		}
+		if errors.Is(err, models.ErrInvalidReviewSLA) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "review_sla_hours must be positive")
+			return
+		}
+		if errors.Is(err, models.ErrUnknownSLAAction) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "sla_action must be reassign or escalate")
+			return
+		}
+		if errors.Is(err, models.ErrTeamLeadRequired) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "team_lead is required when review_sla_hours is set")
+			return
+		}
+		if errors.Is(err, models.ErrUserNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "team lead not found")
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	settingsResp := models.TeamSettingsResponse{Settings: settings}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(settingsResp)
+}
+
+func (h *TeamHandler) GetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	codeOwners, err := h.s.GetCodeOwners(r.Context(), teamName)
+	if err != nil {
+		if errors.Is(err, models.ErrTeamNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	codeOwnersResp := models.TeamCodeOwnersResponse{CodeOwners: codeOwners}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(codeOwnersResp)
+}
+
+func (h *TeamHandler) UploadCodeOwners(w http.ResponseWriter, r *http.Request) {
+	var codeOwners models.TeamCodeOwners
+	err := json.NewDecoder(r.Body).Decode(&codeOwners)
+	if err != nil {
+		writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid JSON")
+		return
+	}
+
+	codeOwners, err = h.s.UploadCodeOwners(r.Context(), codeOwners)
+	if err != nil {
+		if errors.Is(err, models.ErrTeamNotFound) {
+			writeHTTPError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		}
+		if errors.Is(err, models.ErrInvalidCodeOwners) {
+			writeHTTPError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+			return
+		}
+		writeHTTPError(w, http.StatusInternalServerError, "INTERNAL", err.Error())
+		return
+	}
+	codeOwnersResp := models.TeamCodeOwnersResponse{CodeOwners: codeOwners}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(codeOwnersResp)
+}
+
 func writeHTTPError(w http.ResponseWriter, status int, code, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
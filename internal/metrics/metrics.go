@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	OutcomeAssigned    = "assigned"
+	OutcomePartial     = "partial"
+	OutcomeNoCandidate = "no_candidate"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Number of HTTP requests by route and status code.",
+	}, []string{"method", "route", "code"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+	reviewerAssignments = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "reviewer_assignments_total",
+		Help: "Committed reviewer assignment attempts by action and outcome.",
+	}, []string{"action", "outcome"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		reviewerAssignments,
+	)
+}
+
+func ObserveAssignment(action string, requested, assigned int) {
+	if requested == 0 {
+		return
+	}
+	outcome := OutcomeAssigned
+	switch {
+	case assigned == 0:
+		outcome = OutcomeNoCandidate
+	case assigned < requested:
+		outcome = OutcomePartial
+	}
+	reviewerAssignments.WithLabelValues(action, outcome).Inc()
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		route := "unmatched"
+		if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil && routeCtx.RoutePattern() != "" {
+			route = routeCtx.RoutePattern()
+		}
+		httpRequests.WithLabelValues(r.Method, route, fmt.Sprint(recorder.status)).Inc()
+		httpDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+	})
+}
+
+func Handler(extra ...prometheus.Collector) http.Handler {
+	registry.MustRegister(extra...)
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObserveAssignmentOutcome(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested int
+		assigned  int
+		outcome   string
+	}{
+		{name: "all slots filled", requested: 2, assigned: 2, outcome: OutcomeAssigned},
+		{name: "some slots filled", requested: 3, assigned: 1, outcome: OutcomePartial},
+		{name: "no candidates", requested: 2, assigned: 0, outcome: OutcomeNoCandidate},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reviewerAssignments.Reset()
+			ObserveAssignment("CREATE", tt.requested, tt.assigned)
+			for _, outcome := range []string{OutcomeAssigned, OutcomePartial, OutcomeNoCandidate} {
+				want := 0.0
+				if outcome == tt.outcome {
+					want = 1
+				}
+				if got := testutil.ToFloat64(reviewerAssignments.WithLabelValues("CREATE", outcome)); got != want {
+					t.Errorf("%s = %v, want %v", outcome, got, want)
+				}
+			}
+		})
+	}
+
+	reviewerAssignments.Reset()
+	ObserveAssignment("CREATE", 0, 0)
+	if got := testutil.CollectAndCount(reviewerAssignments); got != 0 {
+		t.Errorf("nothing requested recorded %d series, want 0", got)
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+type Absence struct {
+	Id           int64     `json:"absence_id"`
+	UserID       string    `json:"user_id"`
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+	Reason       string    `json:"reason,omitempty"`
+	AutoReassign bool      `json:"auto_reassign"`
+}
+
+type AbsenceResponse struct {
+	Absence Absence `json:"absence"`
+}
+
+type AbsencesResponse struct {
+	UserID   string    `json:"user_id"`
+	Absences []Absence `json:"absences"`
+}
+
+var ErrInvalidAbsencePeriod = errors.New("absence must end after it starts")
+var ErrAbsenceNotFound = errors.New("absence not found")
@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+const (
+	ExclusionAuthor          = "author"
+	ExclusionInactive        = "inactive"
+	ExclusionAbsent          = "absent"
+	ExclusionAlreadyAssigned = "already_assigned"
+	ExclusionAtCapacity      = "at_capacity"
+	ExclusionFallbackUnused  = "fallback_not_needed"
+	ExclusionOther           = "other"
+)
+
+const (
+	AssignmentActionCreate     = "CREATE"
+	AssignmentActionReassign   = "REASSIGN"
+	AssignmentActionFill       = "FILL"
+	AssignmentActionReady      = "READY"
+	AssignmentActionReopen     = "REOPEN"
+	AssignmentActionDecline    = "DECLINE"
+	AssignmentActionSLA        = "SLA"
+	AssignmentActionDeactivate = "DEACTIVATE"
+)
+
+type MemberAvailability struct {
+	UserID          string
+	TeamName        string
+	IsActive        bool
+	Absent          bool
+	AlreadyAssigned bool
+	OpenReviews     int
+	MaxOpenReviews  *int
+	Skills          []string
+	TimeZone        string
+	WorkStart       string
+	WorkEnd         string
+}
+
+type ExcludedUser struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+	Reason   string `json:"reason"`
+}
+
+type SelectedReviewer struct {
+	UserID string `json:"user_id"`
+	Rule   string `json:"rule"`
+}
+
+type AssignmentDecision struct {
+	Id               int64               `json:"decision_id"`
+	PullRequestID    string              `json:"pull_request_id"`
+	Action           string              `json:"action"`
+	Strategy         string              `json:"strategy"`
+	ReplacedReviewer string              `json:"replaced_reviewer,omitempty"`
+	Candidates       []ReviewerCandidate `json:"candidates"`
+	Excluded         []ExcludedUser      `json:"excluded"`
+	Selected         []SelectedReviewer  `json:"selected"`
+	DecidedAt        time.Time           `json:"decided_at"`
+}
+
+type AssignmentLogResponse struct {
+	PullRequestID string               `json:"pull_request_id"`
+	Decisions     []AssignmentDecision `json:"decisions"`
+}
+
+const (
+	UnassignReassign   = AssignmentActionReassign
+	UnassignDecline    = AssignmentActionDecline
+	UnassignSLA        = AssignmentActionSLA
+	UnassignDeactivate = AssignmentActionDeactivate
+	UnassignClose      = "CLOSE"
+)
@@ -0,0 +1,20 @@
+package models
+
+import "errors"
+
+type CodeOwnersRule struct {
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+type TeamCodeOwners struct {
+	TeamName string           `json:"team_name"`
+	Content  string           `json:"content"`
+	Rules    []CodeOwnersRule `json:"rules"`
+}
+
+type TeamCodeOwnersResponse struct {
+	CodeOwners TeamCodeOwners `json:"codeowners"`
+}
+
+var ErrInvalidCodeOwners = errors.New("invalid CODEOWNERS file")
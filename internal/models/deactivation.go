@@ -0,0 +1,33 @@
+package models
+
+import "errors"
+
+type ReleasedReview struct {
+	PullRequestID string
+	ReviewerID    string
+	TeamName      string
+}
+
+type ReviewerAssignment struct {
+	PullRequestID string
+	ReviewerID    string
+}
+
+type ReviewerReplacement struct {
+	OldReviewerID string `json:"old_reviewer_id"`
+	NewReviewerID string `json:"new_reviewer_id"`
+}
+
+type PullRequestReassignment struct {
+	PullRequestID   string                `json:"pull_request_id"`
+	Replacements    []ReviewerReplacement `json:"replacements"`
+	UnresolvedSlots []string              `json:"unresolved_slots"`
+	KeptApprovals   []string              `json:"kept_approvals,omitempty"`
+}
+
+type DeactivationReport struct {
+	DeactivatedUsers []string                  `json:"deactivated_users"`
+	PullRequests     []PullRequestReassignment `json:"pull_requests"`
+}
+
+var ErrNothingToDeactivate = errors.New("user_ids or team_name is required")
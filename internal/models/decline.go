@@ -0,0 +1,13 @@
+package models
+
+import "errors"
+
+const (
+	DeclineConflictOfInterest = "CONFLICT_OF_INTEREST"
+	DeclineNoExpertise        = "NO_EXPERTISE"
+	DeclineOverloaded         = "OVERLOADED"
+)
+
+var DeclineReasons = []string{DeclineConflictOfInterest, DeclineNoExpertise, DeclineOverloaded}
+
+var ErrInvalidDeclineReason = errors.New("invalid decline reason")
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+const (
+	EventCreated          = "CREATED"
+	EventStatusChanged    = "STATUS_CHANGED"
+	EventReviewerAssigned = "REVIEWER_ASSIGNED"
+	EventReviewerReplaced = "REVIEWER_REPLACED"
+	EventReviewerRemoved  = "REVIEWER_REMOVED"
+	EventReviewSubmitted  = "REVIEW_SUBMITTED"
+	EventForceMerged      = "FORCE_MERGED"
+)
+
+type PullRequestEvent struct {
+	Id            int64     `json:"event_id"`
+	PullRequestID string    `json:"pull_request_id"`
+	Type          string    `json:"type"`
+	Actor         *string   `json:"actor,omitempty"`
+	OldValue      *string   `json:"old_value,omitempty"`
+	NewValue      *string   `json:"new_value,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type TimelineResponse struct {
+	PullRequestID string             `json:"pull_request_id"`
+	Events        []PullRequestEvent `json:"events"`
+}
@@ -6,25 +6,45 @@ import (
 )
 
 type PullRequest struct {
-	Id                string     `json:"pull_request_id"`
-	Name              string     `json:"pull_request_name"`
-	AuthorID          string     `json:"author_id"`
-	Status            string     `json:"status"`
-	AssignedReviewers []string   `json:"assigned_reviewers"`
-	CreatedAt         time.Time  `json:"createdAt"`
-	MergedAt          *time.Time `json:"mergedAt,omitempty"`
+	Id                 string     `json:"pull_request_id"`
+	Name               string     `json:"pull_request_name"`
+	AuthorID           string     `json:"author_id"`
+	Status             string     `json:"status"`
+	AssignedReviewers  []string   `json:"assigned_reviewers"`
+	Reviews            []Review   `json:"reviews"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	MergedAt           *time.Time `json:"mergedAt,omitempty"`
+	ClosedAt           *time.Time `json:"closedAt,omitempty"`
+	Labels             []string   `json:"labels,omitempty"`
+	ChangedFiles       []string   `json:"changed_files,omitempty"`
+	UncoveredLabels    []string   `json:"uncovered_labels,omitempty"`
+	CrossTeamReviewers []string   `json:"cross_team_reviewers,omitempty"`
+	RequiredReviewers  int        `json:"required_reviewers"`
+	PendingAssignment  bool       `json:"pending_assignment"`
 }
 
-type PullRequestShort struct {
-	Id       string `json:"pull_request_id"`
-	Name     string `json:"pull_request_name"`
-	AuthorID string `json:"author_id"`
-	Status   string `json:"status"`
+const (
+	ReviewPending          = "PENDING"
+	ReviewApproved         = "APPROVED"
+	ReviewChangesRequested = "CHANGES_REQUESTED"
+	ReviewCommented        = "COMMENTED"
+)
+
+type Review struct {
+	ReviewerID string     `json:"reviewer_id"`
+	State      string     `json:"state"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
 }
 
-type ReviewerStat struct {
-	ReviewerID string `json:"reviewer_id"`
-	AssignStat int    `json:"assign_stat"`
+type PullRequestShort struct {
+	Id             string   `json:"pull_request_id"`
+	Name           string   `json:"pull_request_name"`
+	AuthorID       string   `json:"author_id"`
+	Status         string   `json:"status"`
+	ReviewersCount *int     `json:"reviewers_count,omitempty"`
+	ChangedFiles   []string `json:"changed_files,omitempty"`
+	Labels         []string `json:"labels,omitempty"`
+	Draft          bool     `json:"draft,omitempty"`
 }
 
 type PullRequestsByReviewerResponse struct {
@@ -37,6 +57,28 @@ type ReassignResponse struct {
 	ReplacedBy  string      `json:"replaced_by"`
 }
 
+type RankedCandidate struct {
+	Rank int `json:"rank"`
+	ReviewerCandidate
+}
+
+type AssignmentPreview struct {
+	TeamName           string            `json:"team_name"`
+	RequiredReviewers  int               `json:"required_reviewers"`
+	Reviewers          []string          `json:"reviewers"`
+	CrossTeamReviewers []string          `json:"cross_team_reviewers,omitempty"`
+	UncoveredLabels    []string          `json:"uncovered_labels,omitempty"`
+	Candidates         []RankedCandidate `json:"candidates"`
+}
+
+type AssignmentPreviewResponse struct {
+	Preview AssignmentPreview `json:"preview"`
+}
+
+type PullRequestsResponse struct {
+	PullRequests []PullRequest `json:"pull_requests"`
+}
+
 type PullRequestResponse struct {
 	PullRequest PullRequest `json:"pr"`
 }
@@ -45,3 +87,9 @@ var ErrPullRequestExist = errors.New("pull request already exists")
 var ErrPullRequestNotFound = errors.New("pull request not found")
 var ErrPullRequestAlreadyMerged = errors.New("pull request already merged")
 var ErrUserNotReviewer = errors.New("reviewer is not assigned to this PR")
+var ErrInvalidReviewState = errors.New("invalid review state")
+var ErrNotEnoughApprovals = errors.New("not enough approvals to merge")
+var ErrForceMergeForbidden = errors.New("only the team lead can force a merge")
+var ErrActorRequired = errors.New("X-Actor-ID header is required to force a merge")
+var ErrPullRequestNotOpen = errors.New("pull request is not open")
+var ErrInvalidStatusTransition = errors.New("invalid pull request status transition")
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	SLAActionReassign = "reassign"
+	SLAActionEscalate = "escalate"
+)
+
+var SLAActions = []string{SLAActionReassign, SLAActionEscalate}
+
+type PendingReview struct {
+	PullRequestID string
+	ReviewerID    string
+	TeamName      string
+	AssignedAt    time.Time
+	TimeZone      string
+	WorkStart     string
+	WorkEnd       string
+	SLAHours      int
+	SLAAction     string
+	TeamLead      *string
+	Escalated     bool
+}
+
+type OverdueReview struct {
+	PullRequestID string    `json:"pull_request_id"`
+	ReviewerID    string    `json:"reviewer_id"`
+	TeamName      string    `json:"team_name"`
+	AssignedAt    time.Time `json:"assigned_at"`
+	Deadline      time.Time `json:"deadline"`
+	Escalated     bool      `json:"escalated"`
+}
+
+type ReviewEscalation struct {
+	PullRequestID string
+	ReviewerID    string
+	AssignedAt    time.Time
+	Action        string
+	EscalatedTo   *string
+	EscalatedAt   time.Time
+}
+
+type OverdueReviewsResponse struct {
+	Reviews []OverdueReview `json:"reviews"`
+}
+
+var ErrInvalidReviewSLA = errors.New("review sla hours must be positive")
+var ErrUnknownSLAAction = errors.New("unknown sla action")
+var ErrTeamLeadRequired = errors.New("team lead is required for review SLA")
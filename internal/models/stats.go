@@ -0,0 +1,111 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	BucketDay   = "day"
+	BucketWeek  = "week"
+	BucketMonth = "month"
+)
+
+var StatBuckets = []string{BucketDay, BucketWeek, BucketMonth}
+
+type StatsFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName string
+	Status   string
+	Bucket   string
+}
+
+type ReviewCounts struct {
+	AssignStat     int            `json:"assign_stat"`
+	OpenStat       int            `json:"open_stat"`
+	CompletedStat  int            `json:"completed_stat"`
+	ReassignedAway int            `json:"reassigned_away"`
+	DeclineStat    int            `json:"decline_stat"`
+	DeclineReasons map[string]int `json:"decline_reasons,omitempty"`
+}
+
+type ReviewerStatPoint struct {
+	Bucket time.Time `json:"bucket"`
+	ReviewCounts
+}
+
+type ReviewerStat struct {
+	ReviewerID string `json:"reviewer_id"`
+	ReviewCounts
+	Series []ReviewerStatPoint `json:"series,omitempty"`
+}
+
+var ErrInvalidStatsFilter = errors.New("invalid stats filter")
+
+type DurationStats struct {
+	Count         int      `json:"count"`
+	MedianSeconds *float64 `json:"median_seconds"`
+	P90Seconds    *float64 `json:"p90_seconds"`
+}
+
+type ReviewMetrics struct {
+	TimeToMerge       DurationStats `json:"time_to_merge"`
+	TimeToFirstReview DurationStats `json:"time_to_first_review"`
+	Reassigns         int           `json:"reassigns"`
+}
+
+type TeamMetrics struct {
+	TeamName string `json:"team_name"`
+	ReviewMetrics
+}
+
+type ReviewerMetrics struct {
+	ReviewerID string `json:"reviewer_id"`
+	ReviewMetrics
+}
+
+type MetricsResponse struct {
+	Teams     []TeamMetrics     `json:"teams"`
+	Reviewers []ReviewerMetrics `json:"reviewers"`
+}
+
+const (
+	WorkloadOverloaded  = "overloaded"
+	WorkloadUnderloaded = "underloaded"
+)
+
+type MemberWorkload struct {
+	TeamName    string `json:"-"`
+	UserID      string `json:"user_id"`
+	IsActive    bool   `json:"is_active"`
+	Assignments int    `json:"assignments"`
+	Outlier     string `json:"outlier,omitempty"`
+}
+
+type TeamFairness struct {
+	TeamName          string           `json:"team_name"`
+	TotalAssignments  int              `json:"total_assignments"`
+	Gini              float64          `json:"gini"`
+	MaxMinRatio       *float64         `json:"max_min_ratio"`
+	ZeroReviewMembers []string         `json:"zero_review_members"`
+	Members           []MemberWorkload `json:"members"`
+}
+
+type FairnessResponse struct {
+	Teams []TeamFairness `json:"teams"`
+}
+
+type ReviewPairing struct {
+	AuthorID   string `json:"author_id"`
+	ReviewerID string `json:"reviewer_id"`
+	Reviews    int    `json:"reviews"`
+}
+
+type PairingMatrix struct {
+	TeamName string   `json:"team_name"`
+	Members  []string `json:"members"`
+	Matrix   [][]int  `json:"matrix"`
+}
+
+var ErrTeamNameRequired = errors.New("team_name is required")
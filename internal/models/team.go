@@ -8,14 +8,62 @@ type Team struct {
 }
 
 type TeamMember struct {
-	Id       string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	Id             string   `json:"user_id"`
+	Username       string   `json:"username"`
+	IsActive       bool     `json:"is_active"`
+	Skills         []string `json:"skills,omitempty"`
+	TimeZone       string   `json:"time_zone,omitempty"`
+	WorkStart      string   `json:"work_start,omitempty"`
+	WorkEnd        string   `json:"work_end,omitempty"`
+	MaxOpenReviews *int     `json:"max_open_reviews,omitempty"`
 }
 
 type TeamResponse struct {
 	Team Team `json:"team"`
 }
 
+const (
+	StrategyRandom      = "random"
+	StrategyRoundRobin  = "round_robin"
+	StrategyLeastLoaded = "least_loaded"
+	StrategyWeighted    = "weighted"
+)
+
+var AssignmentStrategies = []string{StrategyRandom, StrategyRoundRobin, StrategyLeastLoaded, StrategyWeighted}
+
+const DefaultReviewersCount = 2
+
+type TeamSettings struct {
+	TeamName             string   `json:"team_name"`
+	AssignmentStrategy   string   `json:"assignment_strategy"`
+	ReviewersCount       int      `json:"reviewers_count"`
+	FallbackTeams        []string `json:"fallback_teams"`
+	MaxOpenReviews       *int     `json:"max_open_reviews,omitempty"`
+	RequiredApprovals    int      `json:"required_approvals"`
+	ReassignOnDeactivate bool     `json:"reassign_on_deactivate"`
+	ReviewSLAHours       *int     `json:"review_sla_hours,omitempty"`
+	SLAAction            string   `json:"sla_action"`
+	TeamLead             *string  `json:"team_lead,omitempty"`
+}
+
+type TeamSettingsResponse struct {
+	Settings TeamSettings `json:"settings"`
+}
+
+func DefaultTeamSettings(teamName string) TeamSettings {
+	return TeamSettings{
+		TeamName:           teamName,
+		AssignmentStrategy: StrategyLeastLoaded,
+		ReviewersCount:     DefaultReviewersCount,
+		SLAAction:          SLAActionReassign,
+	}
+}
+
 var ErrTeamExist = errors.New("team already exist")
 var ErrTeamNotFound = errors.New("team not found")
+var ErrInvalidReviewersCount = errors.New("reviewers count must be positive")
+var ErrInvalidFallbackTeam = errors.New("team cannot fall back to itself")
+var ErrInvalidCapacity = errors.New("max open reviews must be positive")
+var ErrInvalidRequiredApprovals = errors.New("required approvals cannot be negative")
+var ErrApprovalsExceedReviewers = errors.New("required approvals cannot exceed reviewers count")
+var ErrUnknownAssignmentStrategy = errors.New("unknown assignment strategy")
@@ -3,16 +3,33 @@ package models
 import "errors"
 
 type User struct {
-	Id       string `json:"user_id"`
-	Username string `json:"username"`
-	TeamName string `json:"team_name"`
-	IsActive bool   `json:"is_active"`
+	Id             string   `json:"user_id"`
+	Username       string   `json:"username"`
+	TeamName       string   `json:"team_name"`
+	IsActive       bool     `json:"is_active"`
+	Skills         []string `json:"skills,omitempty"`
+	TimeZone       string   `json:"time_zone,omitempty"`
+	WorkStart      string   `json:"work_start,omitempty"`
+	WorkEnd        string   `json:"work_end,omitempty"`
+	MaxOpenReviews *int     `json:"max_open_reviews,omitempty"`
+}
+
+type ReviewerCandidate struct {
+	UserID      string   `json:"user_id"`
+	TeamName    string   `json:"team_name"`
+	OpenReviews int      `json:"open_reviews"`
+	Skills      []string `json:"skills,omitempty"`
+	TimeZone    string   `json:"time_zone,omitempty"`
+	WorkStart   string   `json:"work_start,omitempty"`
+	WorkEnd     string   `json:"work_end,omitempty"`
 }
 
 type UserResponse struct {
-	User User `json:"user"`
+	User                   User                      `json:"user"`
+	ReassignedPullRequests []PullRequestReassignment `json:"reassigned_pull_requests,omitempty"`
 }
 
 var ErrUserNotFound = errors.New("user not found")
 var ErrAuthorNotFound = errors.New("author not found")
+var ErrInvalidWorkingHours = errors.New("invalid time zone or working hours")
 var ErrNotEnoughMembersInTeam = errors.New("not enough members in team")
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"pull-request-reviewers-service/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AbsenceRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAbsenceRepository(db *pgxpool.Pool) *AbsenceRepository {
+	return &AbsenceRepository{db: db}
+}
+
+func (r *AbsenceRepository) CreateAbsence(ctx context.Context, absence models.Absence) (models.Absence, error) {
+	err := r.db.QueryRow(ctx, `INSERT INTO user_absences (user_id, starts_at, ends_at, reason, auto_reassign)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING absence_id`, absence.UserID, absence.StartsAt, absence.EndsAt, absence.Reason, absence.AutoReassign).Scan(&absence.Id)
+	if err != nil {
+		return models.Absence{}, err
+	}
+	return absence, nil
+}
+
+func (r *AbsenceRepository) GetAbsences(ctx context.Context, userID string) ([]models.Absence, error) {
+	rows, err := r.db.Query(ctx, `SELECT absence_id, user_id, starts_at, ends_at, reason, auto_reassign
+FROM user_absences
+WHERE user_id = $1
+ORDER BY starts_at`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAbsences(rows)
+}
+
+func (r *AbsenceRepository) DeleteAbsence(ctx context.Context, absenceID int64) error {
+	var id int64
+	err := r.db.QueryRow(ctx, `DELETE FROM user_absences WHERE absence_id = $1 RETURNING absence_id`, absenceID).Scan(&id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *AbsenceRepository) GetStartedAbsences(ctx context.Context) ([]models.Absence, error) {
+	rows, err := r.db.Query(ctx, `SELECT absence_id, user_id, starts_at, ends_at, reason, auto_reassign
+FROM user_absences
+WHERE auto_reassign IS TRUE AND reassigned IS FALSE AND starts_at <= now() AND ends_at > now()
+ORDER BY absence_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanAbsences(rows)
+}
+
+func (r *AbsenceRepository) LockStartedAbsence(ctx context.Context, tx pgx.Tx, absenceID int64) (bool, error) {
+	var id int64
+	err := tx.QueryRow(ctx, `SELECT absence_id
+FROM user_absences
+WHERE absence_id = $1 AND auto_reassign IS TRUE AND reassigned IS FALSE AND starts_at <= now() AND ends_at > now()
+FOR UPDATE SKIP LOCKED`, absenceID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *AbsenceRepository) MarkAbsenceReassigned(ctx context.Context, tx pgx.Tx, absenceID int64) error {
+	_, err := tx.Exec(ctx, `UPDATE user_absences SET reassigned = TRUE WHERE absence_id = $1`, absenceID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func scanAbsences(rows pgx.Rows) ([]models.Absence, error) {
+	var absences []models.Absence
+	for rows.Next() {
+		var absence models.Absence
+		err := rows.Scan(&absence.Id, &absence.UserID, &absence.StartsAt, &absence.EndsAt, &absence.Reason, &absence.AutoReassign)
+		if err != nil {
+			return nil, err
+		}
+		absences = append(absences, absence)
+	}
+	return absences, rows.Err()
+}
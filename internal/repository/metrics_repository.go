@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+	"pull-request-reviewers-service/internal/models"
+)
+
+const teamMergeQuery = `SELECT u.team_name, COUNT(*),
+percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)),
+percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at))
+FROM pull_requests pr
+JOIN users u ON u.user_id = pr.author_id
+WHERE pr.status = 'MERGED' AND ($1::timestamptz IS NULL OR pr.created_at >= $1) AND ($2::timestamptz IS NULL OR pr.created_at < $2)
+AND ($3 = '' OR u.team_name = $3)
+GROUP BY 1`
+
+const reviewerMergeQuery = `SELECT a.reviewer_id, COUNT(*),
+percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)),
+percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at))
+FROM review_assignments a
+JOIN pull_requests pr ON pr.pull_request_id = a.pull_request_id
+JOIN users u ON u.user_id = a.reviewer_id
+WHERE pr.status = 'MERGED' AND a.unassigned_at IS NULL
+AND ($1::timestamptz IS NULL OR pr.created_at >= $1) AND ($2::timestamptz IS NULL OR pr.created_at < $2)
+AND ($3 = '' OR u.team_name = $3)
+GROUP BY 1`
+
+const teamAssignmentQuery = `SELECT u.team_name, COUNT(a.first_reviewed_at),
+percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM a.first_reviewed_at - a.assigned_at)),
+percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM a.first_reviewed_at - a.assigned_at)),
+COUNT(*) FILTER (WHERE a.unassign_reason IN ('REASSIGN', 'DECLINE', 'SLA', 'DEACTIVATE'))
+FROM review_assignments a
+JOIN pull_requests pr ON pr.pull_request_id = a.pull_request_id
+JOIN users u ON u.user_id = pr.author_id
+WHERE ($1::timestamptz IS NULL OR a.assigned_at >= $1) AND ($2::timestamptz IS NULL OR a.assigned_at < $2)
+AND ($3 = '' OR u.team_name = $3)
+GROUP BY 1`
+
+const reviewerAssignmentQuery = `SELECT a.reviewer_id, COUNT(a.first_reviewed_at),
+percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM a.first_reviewed_at - a.assigned_at)),
+percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM a.first_reviewed_at - a.assigned_at)),
+COUNT(*) FILTER (WHERE a.unassign_reason IN ('REASSIGN', 'DECLINE', 'SLA', 'DEACTIVATE'))
+FROM review_assignments a
+JOIN users u ON u.user_id = a.reviewer_id
+WHERE ($1::timestamptz IS NULL OR a.assigned_at >= $1) AND ($2::timestamptz IS NULL OR a.assigned_at < $2)
+AND ($3 = '' OR u.team_name = $3)
+GROUP BY 1`
+
+func (r *PullRequestRepository) GetTeamMetrics(ctx context.Context, filter models.StatsFilter) (map[string]models.ReviewMetrics, error) {
+	return r.getReviewMetrics(ctx, filter, teamMergeQuery, teamAssignmentQuery)
+}
+
+func (r *PullRequestRepository) GetReviewerMetrics(ctx context.Context, filter models.StatsFilter) (map[string]models.ReviewMetrics, error) {
+	return r.getReviewMetrics(ctx, filter, reviewerMergeQuery, reviewerAssignmentQuery)
+}
+
+func (r *PullRequestRepository) getReviewMetrics(ctx context.Context, filter models.StatsFilter, mergeQuery, assignmentQuery string) (map[string]models.ReviewMetrics, error) {
+	metrics := make(map[string]models.ReviewMetrics)
+
+	rows, err := r.db.Query(ctx, mergeQuery, filter.From, filter.To, filter.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var key string
+		var stats models.DurationStats
+		if err = rows.Scan(&key, &stats.Count, &stats.MedianSeconds, &stats.P90Seconds); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		m := metrics[key]
+		m.TimeToMerge = stats
+		metrics[key] = m
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = r.db.Query(ctx, assignmentQuery, filter.From, filter.To, filter.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key string
+		var stats models.DurationStats
+		var reassigns int
+		if err = rows.Scan(&key, &stats.Count, &stats.MedianSeconds, &stats.P90Seconds, &reassigns); err != nil {
+			return nil, err
+		}
+		m := metrics[key]
+		m.TimeToFirstReview = stats
+		m.Reassigns = reassigns
+		metrics[key] = m
+	}
+	return metrics, rows.Err()
+}
+
+func (r *PullRequestRepository) CountPullRequestsByStatus(ctx context.Context) (map[string]int, error) {
+	return r.countBy(ctx, `SELECT status, COUNT(*) FROM pull_requests GROUP BY status`)
+}
+
+func (r *PullRequestRepository) CountActiveMembersByTeam(ctx context.Context) (map[string]int, error) {
+	return r.countBy(ctx, `SELECT t.team_name, COUNT(u.user_id)
+FROM teams t
+LEFT JOIN users u ON u.team_name = t.team_name AND u.is_active IS TRUE
+GROUP BY t.team_name`)
+}
+
+func (r *PullRequestRepository) countBy(ctx context.Context, query string) (map[string]int, error) {
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var key string
+		var count int
+		if err = rows.Scan(&key, &count); err != nil {
+			return nil, err
+		}
+		counts[key] = count
+	}
+	return counts, rows.Err()
+}
+
+func (r *PullRequestRepository) GetMemberWorkloads(ctx context.Context, filter models.StatsFilter) ([]models.MemberWorkload, error) {
+	rows, err := r.db.Query(ctx, `SELECT u.team_name, u.user_id, u.is_active, COUNT(a.assignment_id)
+FROM users u
+LEFT JOIN review_assignments a ON a.reviewer_id = u.user_id
+AND ($1::timestamptz IS NULL OR a.assigned_at >= $1) AND ($2::timestamptz IS NULL OR a.assigned_at < $2)
+WHERE ($3 = '' OR u.team_name = $3)
+GROUP BY u.team_name, u.user_id
+ORDER BY u.team_name, u.user_id`, filter.From, filter.To, filter.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var workloads []models.MemberWorkload
+	for rows.Next() {
+		var workload models.MemberWorkload
+		if err = rows.Scan(&workload.TeamName, &workload.UserID, &workload.IsActive, &workload.Assignments); err != nil {
+			return nil, err
+		}
+		workloads = append(workloads, workload)
+	}
+	return workloads, rows.Err()
+}
+
+func (r *PullRequestRepository) GetReviewPairings(ctx context.Context, filter models.StatsFilter) ([]models.ReviewPairing, error) {
+	rows, err := r.db.Query(ctx, `SELECT pr.author_id, a.reviewer_id, COUNT(*)
+FROM review_assignments a
+JOIN pull_requests pr ON pr.pull_request_id = a.pull_request_id
+JOIN users au ON au.user_id = pr.author_id
+JOIN users ru ON ru.user_id = a.reviewer_id
+WHERE a.unassigned_at IS NULL AND au.team_name = $3 AND ru.team_name = $3
+AND ($1::timestamptz IS NULL OR a.assigned_at >= $1) AND ($2::timestamptz IS NULL OR a.assigned_at < $2)
+GROUP BY pr.author_id, a.reviewer_id`, filter.From, filter.To, filter.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pairings []models.ReviewPairing
+	for rows.Next() {
+		var pairing models.ReviewPairing
+		if err = rows.Scan(&pairing.AuthorID, &pairing.ReviewerID, &pairing.Reviews); err != nil {
+			return nil, err
+		}
+		pairings = append(pairings, pairing)
+	}
+	return pairings, rows.Err()
+}
@@ -22,8 +22,10 @@ func (r *PullRequestRepository) BeginTx(ctx context.Context) (pgx.Tx, error) {
 }
 
 func (r *PullRequestRepository) CreatePullRequest(ctx context.Context, tx pgx.Tx, pr models.PullRequest) error {
-	_, err := tx.Exec(ctx, `INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, created_at) 
-VALUES ($1, $2, $3, $4, $5)`, pr.Id, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt)
+	_, err := tx.Exec(ctx, `INSERT INTO pull_requests 
+(pull_request_id, pull_request_name, author_id, status, created_at, labels, changed_files, required_reviewers) 
+VALUES ($1, $2, $3, $4, $5, COALESCE($6::text[], '{}'), COALESCE($7::text[], '{}'), $8)`, pr.Id, pr.Name, pr.AuthorID, pr.Status, pr.CreatedAt,
+		pr.Labels, pr.ChangedFiles, pr.RequiredReviewers)
 	if err != nil {
 		return err
 	}
@@ -39,55 +41,235 @@ func (r *PullRequestRepository) GetUsersTeam(ctx context.Context, userID string)
 	return teamName, nil
 }
 
-func (r *PullRequestRepository) FindPotentialReviewers(ctx context.Context, teamName, authorID string) ([]string, error) {
-	rows, err := r.db.Query(ctx, `SELECT user_id FROM users WHERE team_name = $1 AND user_id != $2 AND is_active IS TRUE`, teamName, authorID)
+func (r *PullRequestRepository) FindPotentialReviewers(ctx context.Context, teamName, authorID string) ([]models.ReviewerCandidate, error) {
+	rows, err := r.db.Query(ctx, `SELECT u.user_id, u.team_name, COUNT(pr.pull_request_id) AS open_reviews, u.skills,
+u.time_zone, to_char(u.work_start, 'HH24:MI'), to_char(u.work_end, 'HH24:MI')
+FROM users u
+LEFT JOIN team_settings ts ON ts.team_name = u.team_name
+LEFT JOIN reviewers rv ON rv.reviewer_id = u.user_id
+LEFT JOIN pull_requests pr ON pr.pull_request_id = rv.pull_request_id AND pr.status = 'OPEN'
+WHERE u.team_name = $1 AND u.user_id != $2 AND u.is_active IS TRUE AND NOT EXISTS (
+SELECT 1 FROM user_absences ab WHERE ab.user_id = u.user_id AND now() >= ab.starts_at AND now() < ab.ends_at)
+GROUP BY u.user_id, ts.max_open_reviews
+HAVING COUNT(pr.pull_request_id) < COALESCE(u.max_open_reviews, ts.max_open_reviews, 2147483647)`, teamName, authorID)
 	if err != nil {
 		return nil, err
 	}
-	var members []string
 	defer rows.Close()
 
+	return scanReviewerCandidates(rows)
+}
+
+func (r *PullRequestRepository) FindNewReviewer(ctx context.Context, tx pgx.Tx, teamName, authorID, prID string) ([]models.ReviewerCandidate, error) {
+	rows, err := tx.Query(ctx, `SELECT u.user_id, u.team_name, COUNT(pr.pull_request_id) AS open_reviews, u.skills,
+u.time_zone, to_char(u.work_start, 'HH24:MI'), to_char(u.work_end, 'HH24:MI')
+FROM users u
+LEFT JOIN team_settings ts ON ts.team_name = u.team_name
+LEFT JOIN reviewers rv ON rv.reviewer_id = u.user_id
+LEFT JOIN pull_requests pr ON pr.pull_request_id = rv.pull_request_id AND pr.status = 'OPEN'
+WHERE u.team_name = $1 AND u.user_id != $2 AND u.is_active IS TRUE AND NOT EXISTS (
+SELECT 1 FROM user_absences ab WHERE ab.user_id = u.user_id AND now() >= ab.starts_at AND now() < ab.ends_at) AND u.user_id NOT IN (
+SELECT reviewer_id FROM reviewers WHERE pull_request_id = $3)
+GROUP BY u.user_id, ts.max_open_reviews
+HAVING COUNT(pr.pull_request_id) < COALESCE(u.max_open_reviews, ts.max_open_reviews, 2147483647)`, teamName, authorID, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanReviewerCandidates(rows)
+}
+
+func scanReviewerCandidates(rows pgx.Rows) ([]models.ReviewerCandidate, error) {
+	var candidates []models.ReviewerCandidate
 	for rows.Next() {
-		var memberID string
-		if err = rows.Scan(&memberID); err != nil {
+		var candidate models.ReviewerCandidate
+		if err := rows.Scan(&candidate.UserID, &candidate.TeamName, &candidate.OpenReviews, &candidate.Skills,
+			&candidate.TimeZone, &candidate.WorkStart, &candidate.WorkEnd); err != nil {
 			return nil, err
 		}
-		members = append(members, memberID)
+		candidates = append(candidates, candidate)
 	}
-	return members, nil
+	return candidates, rows.Err()
 }
 
-func (r *PullRequestRepository) FindNewReviewer(ctx context.Context, tx pgx.Tx, teamName, authorID, prID string) ([]string, error) {
-	rows, err := tx.Query(ctx, `SELECT user_id 
-FROM users 
-WHERE team_name = $1 AND user_id != $2 AND is_active IS TRUE AND user_id NOT IN (
-SELECT reviewer_id FROM reviewers WHERE pull_request_id = $3)`, teamName, authorID, prID)
+func (r *PullRequestRepository) CountAvailableReviewers(ctx context.Context, teamNames []string, authorID string) (int, error) {
+	var available int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FILTER (WHERE available) FROM (
+SELECT NOT EXISTS (
+SELECT 1 FROM user_absences ab WHERE ab.user_id = u.user_id AND now() >= ab.starts_at AND now() < ab.ends_at)
+AND COUNT(pr.pull_request_id) < COALESCE(u.max_open_reviews, ts.max_open_reviews, 2147483647) AS available
+FROM users u
+LEFT JOIN team_settings ts ON ts.team_name = u.team_name
+LEFT JOIN reviewers rv ON rv.reviewer_id = u.user_id
+LEFT JOIN pull_requests pr ON pr.pull_request_id = rv.pull_request_id AND pr.status = 'OPEN'
+WHERE u.team_name = ANY($1) AND u.user_id != $2 AND u.is_active IS TRUE
+GROUP BY u.user_id, ts.max_open_reviews) members`, teamNames, authorID).Scan(&available)
+	if err != nil {
+		return 0, err
+	}
+	return available, nil
+}
+
+func (r *PullRequestRepository) ReserveReviewers(ctx context.Context, tx pgx.Tx, userIDs []string) ([]string, error) {
+	_, err := tx.Exec(ctx, `SELECT user_id FROM users WHERE user_id = ANY($1) ORDER BY user_id FOR NO KEY UPDATE`, userIDs)
 	if err != nil {
 		return nil, err
 	}
 
-	var members []string
+	rows, err := tx.Query(ctx, `SELECT u.user_id
+FROM users u
+LEFT JOIN team_settings ts ON ts.team_name = u.team_name
+LEFT JOIN reviewers rv ON rv.reviewer_id = u.user_id
+LEFT JOIN pull_requests pr ON pr.pull_request_id = rv.pull_request_id AND pr.status = 'OPEN'
+WHERE u.user_id = ANY($1)
+GROUP BY u.user_id, ts.max_open_reviews
+HAVING COUNT(pr.pull_request_id) < COALESCE(u.max_open_reviews, ts.max_open_reviews, 2147483647)`, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fitting []string
+	for rows.Next() {
+		var userID string
+		if err = rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		fitting = append(fitting, userID)
+	}
+	return fitting, rows.Err()
+}
+
+func (r *PullRequestRepository) LockTeamMembers(ctx context.Context, tx pgx.Tx, teamNames []string) error {
+	_, err := tx.Exec(ctx, `SELECT user_id FROM users WHERE team_name = ANY($1) ORDER BY user_id FOR NO KEY UPDATE`, teamNames)
+	return err
+}
+
+func (r *PullRequestRepository) FindUnderstaffedPRs(ctx context.Context) ([]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT pr.pull_request_id
+FROM pull_requests pr
+LEFT JOIN reviewers r ON r.pull_request_id = pr.pull_request_id
+WHERE pr.status = 'OPEN'
+GROUP BY pr.pull_request_id
+HAVING COUNT(r.reviewer_id) < pr.required_reviewers
+ORDER BY pr.created_at`)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
+	var prIDs []string
 	for rows.Next() {
-		var memberID string
-		if err = rows.Scan(&memberID); err != nil {
+		var prID string
+		if err = rows.Scan(&prID); err != nil {
 			return nil, err
 		}
-		members = append(members, memberID)
+		prIDs = append(prIDs, prID)
 	}
-	return members, nil
+	return prIDs, rows.Err()
 }
 
-func (r *PullRequestRepository) UpdateReviewer(ctx context.Context, tx pgx.Tx, prID, newReviewerID, oldReviewerID string) error {
+func (r *PullRequestRepository) LockPullRequest(ctx context.Context, tx pgx.Tx, prID string) error {
+	var id string
+	err := tx.QueryRow(ctx, `SELECT pull_request_id FROM pull_requests WHERE pull_request_id = $1 FOR UPDATE`, prID).Scan(&id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *PullRequestRepository) GetOpenPRsByReviewer(ctx context.Context, tx pgx.Tx, reviewerID string) ([]string, error) {
+	rows, err := tx.Query(ctx, `SELECT pr.pull_request_id
+FROM pull_requests pr
+JOIN reviewers r ON pr.pull_request_id = r.pull_request_id
+WHERE r.reviewer_id = $1 AND pr.status = 'OPEN' AND r.state <> 'APPROVED'
+ORDER BY pr.pull_request_id`, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prIDs []string
+	for rows.Next() {
+		var prID string
+		if err = rows.Scan(&prID); err != nil {
+			return nil, err
+		}
+		prIDs = append(prIDs, prID)
+	}
+	return prIDs, rows.Err()
+}
+
+func (r *PullRequestRepository) ReleaseOpenReviews(ctx context.Context, tx pgx.Tx, reviewerIDs []string, reason string) ([]models.ReleasedReview, error) {
+	rows, err := tx.Query(ctx, `WITH released AS (
+DELETE FROM reviewers rv
+USING pull_requests pr, users u
+WHERE rv.pull_request_id = pr.pull_request_id AND u.user_id = rv.reviewer_id AND pr.status = 'OPEN'
+AND rv.reviewer_id = ANY($1) AND rv.state <> 'APPROVED'
+RETURNING rv.pull_request_id, rv.reviewer_id, u.team_name
+), unassigned AS (
+UPDATE review_assignments a
+SET unassigned_at = now(), unassign_reason = $2
+FROM released
+WHERE a.pull_request_id = released.pull_request_id AND a.reviewer_id = released.reviewer_id AND a.unassigned_at IS NULL
+)
+SELECT pull_request_id, reviewer_id, team_name FROM released`, reviewerIDs, reason)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var released []models.ReleasedReview
+	for rows.Next() {
+		var review models.ReleasedReview
+		if err = rows.Scan(&review.PullRequestID, &review.ReviewerID, &review.TeamName); err != nil {
+			return nil, err
+		}
+		released = append(released, review)
+	}
+	return released, rows.Err()
+}
+
+func (r *PullRequestRepository) GetApprovedOpenReviews(ctx context.Context, tx pgx.Tx, reviewerIDs []string) (map[string][]string, error) {
+	rows, err := tx.Query(ctx, `SELECT rv.pull_request_id, rv.reviewer_id
+FROM reviewers rv
+JOIN pull_requests pr ON pr.pull_request_id = rv.pull_request_id
+WHERE pr.status = 'OPEN' AND rv.reviewer_id = ANY($1) AND rv.state = 'APPROVED'
+ORDER BY rv.pull_request_id, rv.reviewer_id`, reviewerIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	approved := make(map[string][]string)
+	for rows.Next() {
+		var prID, reviewerID string
+		if err = rows.Scan(&prID, &reviewerID); err != nil {
+			return nil, err
+		}
+		approved[prID] = append(approved[prID], reviewerID)
+	}
+	return approved, rows.Err()
+}
+
+func (r *PullRequestRepository) UpdateReviewer(ctx context.Context, tx pgx.Tx, prID, newReviewerID, oldReviewerID, reason string) error {
 	var id string
 	err := tx.QueryRow(ctx, `UPDATE reviewers 
-SET reviewer_id = $1 
+SET reviewer_id = $1, state = 'PENDING', assigned_at = now(), reviewed_at = NULL 
 WHERE pull_request_id = $2 AND reviewer_id = $3 RETURNING reviewer_id`, newReviewerID, prID, oldReviewerID).Scan(&id)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	_, err = tx.Exec(ctx, `UPDATE review_assignments 
+SET unassigned_at = now(), unassign_reason = $3 
+WHERE pull_request_id = $1 AND reviewer_id = $2 AND unassigned_at IS NULL`, prID, oldReviewerID, reason)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `INSERT INTO review_assignments (pull_request_id, reviewer_id) VALUES ($1, $2)`, prID, newReviewerID)
+	return err
 }
 
 func (r *PullRequestRepository) AddReviewers(ctx context.Context, tx pgx.Tx, prID string, reviewersID []string) error {
@@ -96,10 +278,67 @@ func (r *PullRequestRepository) AddReviewers(ctx context.Context, tx pgx.Tx, prI
 		if err != nil {
 			return err
 		}
+		_, err = tx.Exec(ctx, `INSERT INTO review_assignments (pull_request_id, reviewer_id) VALUES ($1, $2)`, prID, reviewerID)
+		if err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+func (r *PullRequestRepository) AddReviewerAssignments(ctx context.Context, tx pgx.Tx, assignments []models.ReviewerAssignment) error {
+	rows := func(i int) ([]any, error) {
+		return []any{assignments[i].PullRequestID, assignments[i].ReviewerID}, nil
+	}
+	_, err := tx.CopyFrom(ctx, pgx.Identifier{"reviewers"}, []string{"pull_request_id", "reviewer_id"},
+		pgx.CopyFromSlice(len(assignments), rows))
+	if err != nil {
+		return err
+	}
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"review_assignments"}, []string{"pull_request_id", "reviewer_id"},
+		pgx.CopyFromSlice(len(assignments), rows))
+	return err
+}
+
+func (r *PullRequestRepository) GetPullRequestsWithReviewers(ctx context.Context, tx pgx.Tx, prIDs []string) (map[string]models.PullRequest, error) {
+	rows, err := tx.Query(ctx, `SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.labels, pr.changed_files,
+COALESCE(array_agg(rv.reviewer_id ORDER BY rv.reviewer_id) FILTER (WHERE rv.reviewer_id IS NOT NULL), '{}')
+FROM pull_requests pr
+LEFT JOIN reviewers rv ON rv.pull_request_id = pr.pull_request_id
+WHERE pr.pull_request_id = ANY($1)
+GROUP BY pr.pull_request_id`, prIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pullRequests := make(map[string]models.PullRequest)
+	for rows.Next() {
+		var pr models.PullRequest
+		err = rows.Scan(&pr.Id, &pr.Name, &pr.AuthorID, &pr.Status, &pr.Labels, &pr.ChangedFiles, &pr.AssignedReviewers)
+		if err != nil {
+			return nil, err
+		}
+		pullRequests[pr.Id] = pr
+	}
+	return pullRequests, rows.Err()
+}
+
+func (r *PullRequestRepository) SetReviewState(ctx context.Context, tx pgx.Tx, prID, reviewerID, state string, reviewedAt time.Time) error {
+	var id string
+	err := tx.QueryRow(ctx, `UPDATE reviewers 
+SET state = $1, reviewed_at = $2 
+WHERE pull_request_id = $3 AND reviewer_id = $4 RETURNING reviewer_id`, state, reviewedAt, prID, reviewerID).Scan(&id)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE review_assignments 
+SET first_reviewed_at = COALESCE(first_reviewed_at, $1) 
+WHERE pull_request_id = $2 AND reviewer_id = $3 AND unassigned_at IS NULL`, reviewedAt, prID, reviewerID)
+	return err
+}
+
 func (r *PullRequestRepository) MergePullRequest(ctx context.Context, tx pgx.Tx, prID string, mergedAt time.Time) error {
 	var id string
 	err := tx.QueryRow(ctx, `UPDATE pull_requests SET status = $1, merged_at = $2 
@@ -110,46 +349,352 @@ WHERE pull_request_id = $3 RETURNING pull_request_id`, "MERGED", mergedAt, prID)
 	return nil
 }
 
+func (r *PullRequestRepository) SetPullRequestStatus(ctx context.Context, tx pgx.Tx, prID, status string, closedAt *time.Time) error {
+	var id string
+	err := tx.QueryRow(ctx, `UPDATE pull_requests SET status = $1, closed_at = $2 
+WHERE pull_request_id = $3 RETURNING pull_request_id`, status, closedAt, prID).Scan(&id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *PullRequestRepository) SetRequiredReviewers(ctx context.Context, tx pgx.Tx, prID string, required int) error {
+	_, err := tx.Exec(ctx, `UPDATE pull_requests SET required_reviewers = $1 WHERE pull_request_id = $2`, required, prID)
+	return err
+}
+
+func (r *PullRequestRepository) RemoveReviewers(ctx context.Context, tx pgx.Tx, prID, reason string) error {
+	_, err := tx.Exec(ctx, `DELETE FROM reviewers WHERE pull_request_id = $1`, prID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `UPDATE review_assignments 
+SET unassigned_at = now(), unassign_reason = $2 
+WHERE pull_request_id = $1 AND unassigned_at IS NULL`, prID, reason)
+	return err
+}
+
 func (r *PullRequestRepository) GetPullRequest(ctx context.Context, tx pgx.Tx, prID string) (models.PullRequest, error) {
 	var pr models.PullRequest
-	err := tx.QueryRow(ctx, `SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+	err := tx.QueryRow(ctx, `SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at, closed_at,
+labels, changed_files, required_reviewers
 FROM pull_requests
-WHERE pull_request_id = $1`, prID).Scan(&pr.Id, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt)
+WHERE pull_request_id = $1`, prID).Scan(&pr.Id, &pr.Name, &pr.AuthorID, &pr.Status, &pr.CreatedAt, &pr.MergedAt, &pr.ClosedAt,
+		&pr.Labels, &pr.ChangedFiles, &pr.RequiredReviewers)
 	if err != nil {
 		return models.PullRequest{}, err
 	}
 
-	rows, err := tx.Query(ctx, `SELECT reviewer_id FROM reviewers WHERE pull_request_id = $1`, pr.Id)
+	rows, err := tx.Query(ctx, `SELECT r.reviewer_id, u.team_name IS DISTINCT FROM a.team_name AS cross_team, r.state, r.reviewed_at
+FROM reviewers r
+JOIN users u ON u.user_id = r.reviewer_id
+JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+JOIN users a ON a.user_id = pr.author_id
+WHERE r.pull_request_id = $1`, pr.Id)
 	if err != nil {
 		return models.PullRequest{}, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
-		var reviewerID string
-		err = rows.Scan(&reviewerID)
+		var review models.Review
+		var crossTeam bool
+		err = rows.Scan(&review.ReviewerID, &crossTeam, &review.State, &review.ReviewedAt)
 		if err != nil {
 			return models.PullRequest{}, err
 		}
-		pr.AssignedReviewers = append(pr.AssignedReviewers, reviewerID)
+		pr.AssignedReviewers = append(pr.AssignedReviewers, review.ReviewerID)
+		pr.Reviews = append(pr.Reviews, review)
+		if crossTeam {
+			pr.CrossTeamReviewers = append(pr.CrossTeamReviewers, review.ReviewerID)
+		}
 	}
+	pr.PendingAssignment = pr.Status == "OPEN" && len(pr.AssignedReviewers) < pr.RequiredReviewers
 
 	return pr, nil
 }
 
-func (r *PullRequestRepository) GetAssignStat(ctx context.Context) ([]models.ReviewerStat, error) {
-	rows, err := r.db.Query(ctx, `SELECT reviewer_id, COUNT(*) AS count FROM reviewers GROUP BY reviewer_id`)
+func (r *PullRequestRepository) GetMembersAvailability(ctx context.Context, tx pgx.Tx, teamNames []string, prID string) ([]models.MemberAvailability, error) {
+	rows, err := tx.Query(ctx, `SELECT u.user_id, u.team_name, u.is_active IS TRUE,
+EXISTS (SELECT 1 FROM user_absences ab WHERE ab.user_id = u.user_id AND now() >= ab.starts_at AND now() < ab.ends_at),
+EXISTS (SELECT 1 FROM reviewers r WHERE r.pull_request_id = $2 AND r.reviewer_id = u.user_id),
+(SELECT COUNT(*) FROM reviewers r
+JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id AND pr.status = 'OPEN'
+WHERE r.reviewer_id = u.user_id),
+COALESCE(u.max_open_reviews, ts.max_open_reviews)
+FROM users u
+LEFT JOIN team_settings ts ON ts.team_name = u.team_name
+WHERE u.team_name = ANY($1)`, teamNames, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var members []models.MemberAvailability
+	for rows.Next() {
+		var m models.MemberAvailability
+		err = rows.Scan(&m.UserID, &m.TeamName, &m.IsActive, &m.Absent, &m.AlreadyAssigned, &m.OpenReviews, &m.MaxOpenReviews)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+func (r *PullRequestRepository) AddAssignmentDecision(ctx context.Context, tx pgx.Tx, decision models.AssignmentDecision) error {
+	_, err := tx.Exec(ctx, `INSERT INTO assignment_decisions 
+(pull_request_id, action, strategy, replaced_reviewer, candidates, excluded, selected, decided_at)
+VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8)`, decision.PullRequestID, decision.Action, decision.Strategy, decision.ReplacedReviewer,
+		decision.Candidates, decision.Excluded, decision.Selected, decision.DecidedAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *PullRequestRepository) GetAssignmentDecisions(ctx context.Context, prID string) ([]models.AssignmentDecision, error) {
+	rows, err := r.db.Query(ctx, `SELECT decision_id, pull_request_id, action, strategy, COALESCE(replaced_reviewer, ''),
+candidates, excluded, selected, decided_at
+FROM assignment_decisions
+WHERE pull_request_id = $1
+ORDER BY decided_at, decision_id`, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var decisions []models.AssignmentDecision
+	for rows.Next() {
+		var d models.AssignmentDecision
+		err = rows.Scan(&d.Id, &d.PullRequestID, &d.Action, &d.Strategy, &d.ReplacedReviewer,
+			&d.Candidates, &d.Excluded, &d.Selected, &d.DecidedAt)
+		if err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, d)
+	}
+	return decisions, rows.Err()
+}
+
+func (r *PullRequestRepository) PullRequestExists(ctx context.Context, prID string) (bool, error) {
+	var exist bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM pull_requests WHERE pull_request_id = $1)`, prID).Scan(&exist)
+	if err != nil {
+		return false, err
+	}
+	return exist, nil
+}
+
+func (r *PullRequestRepository) SetDeclineReason(ctx context.Context, tx pgx.Tx, prID, reviewerID, reason string) error {
+	_, err := tx.Exec(ctx, `UPDATE review_assignments SET decline_reason = $3
+WHERE assignment_id = (
+SELECT assignment_id FROM review_assignments
+WHERE pull_request_id = $1 AND reviewer_id = $2 AND unassign_reason = 'DECLINE'
+ORDER BY assignment_id DESC
+LIMIT 1)`, prID, reviewerID, reason)
+	return err
+}
+
+func (r *PullRequestRepository) FindPendingReviews(ctx context.Context, teamName string) ([]models.PendingReview, error) {
+	rows, err := r.db.Query(ctx, `SELECT rv.pull_request_id, rv.reviewer_id, a.team_name, rv.assigned_at,
+u.time_zone, to_char(u.work_start, 'HH24:MI'), to_char(u.work_end, 'HH24:MI'),
+ts.review_sla_hours, ts.sla_action, ts.team_lead, EXISTS (
+SELECT 1 FROM review_escalations e
+WHERE e.pull_request_id = rv.pull_request_id AND e.reviewer_id = rv.reviewer_id AND e.assigned_at = rv.assigned_at)
+FROM reviewers rv
+JOIN pull_requests pr ON pr.pull_request_id = rv.pull_request_id
+JOIN users a ON a.user_id = pr.author_id
+JOIN team_settings ts ON ts.team_name = a.team_name
+JOIN users u ON u.user_id = rv.reviewer_id
+WHERE pr.status = 'OPEN' AND rv.state = 'PENDING' AND ts.review_sla_hours IS NOT NULL AND ($1 = '' OR a.team_name = $1)
+ORDER BY rv.assigned_at, rv.pull_request_id`, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reviews []models.PendingReview
+	for rows.Next() {
+		var review models.PendingReview
+		if err = rows.Scan(&review.PullRequestID, &review.ReviewerID, &review.TeamName, &review.AssignedAt,
+			&review.TimeZone, &review.WorkStart, &review.WorkEnd,
+			&review.SLAHours, &review.SLAAction, &review.TeamLead, &review.Escalated); err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+	return reviews, rows.Err()
+}
+
+func (r *PullRequestRepository) LockReviewer(ctx context.Context, tx pgx.Tx, prID, reviewerID string) (string, time.Time, error) {
+	var state string
+	var assignedAt time.Time
+	err := tx.QueryRow(ctx, `SELECT state, assigned_at FROM reviewers
+WHERE pull_request_id = $1 AND reviewer_id = $2
+FOR UPDATE`, prID, reviewerID).Scan(&state, &assignedAt)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return state, assignedAt, nil
+}
+
+func (r *PullRequestRepository) GetTeamAvailability(ctx context.Context, tx pgx.Tx, teamName string) ([]models.MemberAvailability, error) {
+	rows, err := tx.Query(ctx, `SELECT u.user_id, u.team_name, u.is_active IS TRUE,
+EXISTS (SELECT 1 FROM user_absences ab WHERE ab.user_id = u.user_id AND now() >= ab.starts_at AND now() < ab.ends_at),
+(SELECT COUNT(*) FROM reviewers r
+JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id AND pr.status = 'OPEN'
+WHERE r.reviewer_id = u.user_id),
+COALESCE(u.max_open_reviews, ts.max_open_reviews), u.skills, u.time_zone,
+to_char(u.work_start, 'HH24:MI'), to_char(u.work_end, 'HH24:MI')
+FROM users u
+LEFT JOIN team_settings ts ON ts.team_name = u.team_name
+WHERE u.team_name = $1
+ORDER BY u.user_id`, teamName)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var stat []models.ReviewerStat
+	var members []models.MemberAvailability
 	for rows.Next() {
-		var st models.ReviewerStat
-		if err = rows.Scan(&st.ReviewerID, &st.AssignStat); err != nil {
+		var m models.MemberAvailability
+		err = rows.Scan(&m.UserID, &m.TeamName, &m.IsActive, &m.Absent, &m.OpenReviews, &m.MaxOpenReviews,
+			&m.Skills, &m.TimeZone, &m.WorkStart, &m.WorkEnd)
+		if err != nil {
 			return nil, err
 		}
-		stat = append(stat, st)
+		members = append(members, m)
+	}
+	return members, rows.Err()
+}
+
+func (r *PullRequestRepository) AddAssignmentDecisions(ctx context.Context, tx pgx.Tx, decisions []models.AssignmentDecision) error {
+	_, err := tx.CopyFrom(ctx, pgx.Identifier{"assignment_decisions"},
+		[]string{"pull_request_id", "action", "strategy", "replaced_reviewer", "candidates", "excluded", "selected", "decided_at"},
+		pgx.CopyFromSlice(len(decisions), func(i int) ([]any, error) {
+			decision := decisions[i]
+			var replacedReviewer *string
+			if decision.ReplacedReviewer != "" {
+				replacedReviewer = &decision.ReplacedReviewer
+			}
+			return []any{decision.PullRequestID, decision.Action, decision.Strategy, replacedReviewer,
+				decision.Candidates, decision.Excluded, decision.Selected, decision.DecidedAt}, nil
+		}))
+	return err
+}
+
+func (r *PullRequestRepository) AddReviewEscalation(ctx context.Context, tx pgx.Tx, escalation models.ReviewEscalation) error {
+	_, err := tx.Exec(ctx, `INSERT INTO review_escalations (pull_request_id, reviewer_id, assigned_at, action, escalated_to, escalated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (pull_request_id, reviewer_id, assigned_at) DO NOTHING`,
+		escalation.PullRequestID, escalation.ReviewerID, escalation.AssignedAt, escalation.Action, escalation.EscalatedTo, escalation.EscalatedAt)
+	return err
+}
+
+func (r *PullRequestRepository) AddEvents(ctx context.Context, tx pgx.Tx, events []models.PullRequestEvent) error {
+	_, err := tx.CopyFrom(ctx, pgx.Identifier{"pull_request_events"},
+		[]string{"pull_request_id", "event_type", "actor", "old_value", "new_value", "created_at"},
+		pgx.CopyFromSlice(len(events), func(i int) ([]any, error) {
+			event := events[i]
+			return []any{event.PullRequestID, event.Type, event.Actor, event.OldValue, event.NewValue, event.CreatedAt}, nil
+		}))
+	return err
+}
+
+func (r *PullRequestRepository) GetEvents(ctx context.Context, prID string) ([]models.PullRequestEvent, error) {
+	rows, err := r.db.Query(ctx, `SELECT event_id, pull_request_id, event_type, actor, old_value, new_value, created_at
+FROM pull_request_events
+WHERE pull_request_id = $1
+ORDER BY event_id`, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]models.PullRequestEvent, 0)
+	for rows.Next() {
+		var event models.PullRequestEvent
+		if err = rows.Scan(&event.Id, &event.PullRequestID, &event.Type, &event.Actor, &event.OldValue, &event.NewValue, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
+
+func (r *PullRequestRepository) GetAssignStat(ctx context.Context, filter models.StatsFilter) ([]models.ReviewerStat, error) {
+	var bucket *string
+	if filter.Bucket != "" {
+		bucket = &filter.Bucket
+	}
+
+	rows, err := r.db.Query(ctx, `
+SELECT a.reviewer_id, date_trunc($5::text, a.assigned_at), COUNT(*),
+COUNT(*) FILTER (WHERE a.unassigned_at IS NULL AND pr.status = 'OPEN'),
+COUNT(*) FILTER (WHERE a.unassigned_at IS NULL AND pr.status = 'MERGED'),
+COUNT(*) FILTER (WHERE a.unassign_reason IN ('REASSIGN', 'DECLINE', 'SLA', 'DEACTIVATE')),
+COUNT(*) FILTER (WHERE a.unassign_reason = 'DECLINE'),
+COUNT(*) FILTER (WHERE a.decline_reason = 'CONFLICT_OF_INTEREST'),
+COUNT(*) FILTER (WHERE a.decline_reason = 'NO_EXPERTISE'),
+COUNT(*) FILTER (WHERE a.decline_reason = 'OVERLOADED')
+FROM review_assignments a
+JOIN pull_requests pr ON pr.pull_request_id = a.pull_request_id
+JOIN users u ON u.user_id = a.reviewer_id
+WHERE ($1::timestamptz IS NULL OR a.assigned_at >= $1) AND ($2::timestamptz IS NULL OR a.assigned_at < $2)
+AND ($3 = '' OR u.team_name = $3) AND ($4 = '' OR pr.status = $4)
+GROUP BY 1, 2
+ORDER BY 1, 2`, filter.From, filter.To, filter.TeamName, filter.Status, bucket)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stat := make([]models.ReviewerStat, 0)
+	for rows.Next() {
+		var reviewerID string
+		var bucketStart *time.Time
+		var counts models.ReviewCounts
+		declines := make([]int, len(models.DeclineReasons))
+		if err = rows.Scan(&reviewerID, &bucketStart, &counts.AssignStat, &counts.OpenStat, &counts.CompletedStat,
+			&counts.ReassignedAway, &counts.DeclineStat, &declines[0], &declines[1], &declines[2]); err != nil {
+			return nil, err
+		}
+		counts.DeclineReasons = declineReasons(declines)
+
+		if len(stat) == 0 || stat[len(stat)-1].ReviewerID != reviewerID {
+			stat = append(stat, models.ReviewerStat{ReviewerID: reviewerID})
+		}
+		st := &stat[len(stat)-1]
+		st.AssignStat += counts.AssignStat
+		st.OpenStat += counts.OpenStat
+		st.CompletedStat += counts.CompletedStat
+		st.ReassignedAway += counts.ReassignedAway
+		st.DeclineStat += counts.DeclineStat
+		for reason, count := range counts.DeclineReasons {
+			if st.DeclineReasons == nil {
+				st.DeclineReasons = make(map[string]int)
+			}
+			st.DeclineReasons[reason] += count
+		}
+		if bucketStart != nil {
+			st.Series = append(st.Series, models.ReviewerStatPoint{Bucket: *bucketStart, ReviewCounts: counts})
+		}
+	}
+	return stat, rows.Err()
+}
+
+func declineReasons(counts []int) map[string]int {
+	var reasons map[string]int
+	for i, count := range counts {
+		if count == 0 {
+			continue
+		}
+		if reasons == nil {
+			reasons = make(map[string]int)
+		}
+		reasons[models.DeclineReasons[i]] = count
 	}
-	return stat, nil
+	return reasons
 }
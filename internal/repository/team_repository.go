@@ -30,11 +30,14 @@ func (r *TeamRepository) CreateUpdateUser(ctx context.Context, tx pgx.Tx, member
 	err := tx.QueryRow(ctx, "SELECT EXISTS(SELECT user_id FROM users WHERE user_id = $1)", member.Id).Scan(&exist)
 	if exist {
 		_, err = tx.Exec(ctx, `UPDATE users 
-SET username = $1, team_name = $2, is_active = $3 
-WHERE user_id = $4`, member.Username, teamName, member.IsActive, member.Id)
+SET username = $1, team_name = $2, is_active = $3, skills = COALESCE($4::text[], '{}'),
+time_zone = $5, work_start = $6::time, work_end = $7::time, max_open_reviews = $8 
+WHERE user_id = $9`, member.Username, teamName, member.IsActive, member.Skills,
+			member.TimeZone, member.WorkStart, member.WorkEnd, member.MaxOpenReviews, member.Id)
 	} else {
-		_, err = tx.Exec(ctx, `INSERT INTO users (user_id, username, team_name, is_active)
-VALUES ($1, $2, $3, $4)`, member.Id, member.Username, teamName, member.IsActive)
+		_, err = tx.Exec(ctx, `INSERT INTO users (user_id, username, team_name, is_active, skills, time_zone, work_start, work_end, max_open_reviews)
+VALUES ($1, $2, $3, $4, COALESCE($5::text[], '{}'), $6, $7::time, $8::time, $9)`, member.Id, member.Username, teamName, member.IsActive, member.Skills,
+			member.TimeZone, member.WorkStart, member.WorkEnd, member.MaxOpenReviews)
 	}
 	if err != nil {
 		return err
@@ -45,9 +48,11 @@ VALUES ($1, $2, $3, $4)`, member.Id, member.Username, teamName, member.IsActive)
 
 func (r *TeamRepository) GetUser(ctx context.Context, userID string) (models.User, error) {
 	var u models.User
-	err := r.DB.QueryRow(ctx, `SELECT user_id, username, team_name, is_active
+	err := r.DB.QueryRow(ctx, `SELECT user_id, username, team_name, is_active, skills,
+time_zone, to_char(work_start, 'HH24:MI'), to_char(work_end, 'HH24:MI'), max_open_reviews
 FROM users
-WHERE user_id = $1`, userID).Scan(&u.Id, &u.Username, &u.TeamName, &u.IsActive)
+WHERE user_id = $1`, userID).Scan(&u.Id, &u.Username, &u.TeamName, &u.IsActive, &u.Skills,
+		&u.TimeZone, &u.WorkStart, &u.WorkEnd, &u.MaxOpenReviews)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return models.User{}, models.ErrUserNotFound
@@ -65,7 +70,8 @@ func (r *TeamRepository) GetTeam(ctx context.Context, name string) (models.Team,
 	}
 
 	var members []models.TeamMember
-	querySelectTeamMembers := `SELECT user_id, username, is_active 
+	querySelectTeamMembers := `SELECT user_id, username, is_active, skills,
+time_zone, to_char(work_start, 'HH24:MI'), to_char(work_end, 'HH24:MI'), max_open_reviews 
 FROM users 
 WHERE team_name = $1`
 	rows, err := r.DB.Query(ctx, querySelectTeamMembers, name)
@@ -76,7 +82,8 @@ WHERE team_name = $1`
 
 	for rows.Next() {
 		var member models.TeamMember
-		if err = rows.Scan(&member.Id, &member.Username, &member.IsActive); err != nil {
+		if err = rows.Scan(&member.Id, &member.Username, &member.IsActive, &member.Skills,
+			&member.TimeZone, &member.WorkStart, &member.WorkEnd, &member.MaxOpenReviews); err != nil {
 			return models.Team{}, err
 		}
 		members = append(members, member)
@@ -88,17 +95,40 @@ WHERE team_name = $1`
 	return team, nil
 }
 
-func (r *TeamRepository) SetIsActiveUser(ctx context.Context, userID string, isActive bool) (models.User, error) {
+func (r *TeamRepository) SetIsActiveUser(ctx context.Context, tx pgx.Tx, userID string, isActive bool) (models.User, error) {
 	var user models.User
-	err := r.DB.QueryRow(ctx, `UPDATE users 
+	err := tx.QueryRow(ctx, `UPDATE users 
 SET is_active = $1 
 WHERE user_id = $2 
-RETURNING user_id, username, team_name, is_active`, isActive, userID).Scan(&user.Id, &user.Username, &user.TeamName, &user.IsActive)
+RETURNING user_id, username, team_name, is_active, skills,
+time_zone, to_char(work_start, 'HH24:MI'), to_char(work_end, 'HH24:MI'), max_open_reviews`, isActive, userID).Scan(&user.Id, &user.Username, &user.TeamName, &user.IsActive, &user.Skills,
+		&user.TimeZone, &user.WorkStart, &user.WorkEnd, &user.MaxOpenReviews)
 	if err != nil {
 		return models.User{}, err
 	}
 	return user, nil
 }
+func (r *TeamRepository) DeactivateUsers(ctx context.Context, tx pgx.Tx, userIDs []string, teamName string) ([]string, error) {
+	rows, err := tx.Query(ctx, `UPDATE users 
+SET is_active = FALSE 
+WHERE user_id = ANY($1) OR team_name = $2 
+RETURNING user_id`, userIDs, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deactivated []string
+	for rows.Next() {
+		var userID string
+		if err = rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		deactivated = append(deactivated, userID)
+	}
+	return deactivated, rows.Err()
+}
+
 func (r *TeamRepository) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]models.PullRequestShort, error) {
 	rows, err := r.DB.Query(ctx, `SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status
 FROM pull_requests pr
@@ -123,3 +153,160 @@ WHERE r.reviewer_id = $1`, reviewerID)
 
 	return pullRequestsShort, nil
 }
+
+func (r *TeamRepository) GetTeamSettings(ctx context.Context, teamName string) (models.TeamSettings, error) {
+	settings := models.DefaultTeamSettings(teamName)
+	err := r.DB.QueryRow(ctx, `SELECT assignment_strategy, reviewers_count, max_open_reviews, required_approvals, reassign_on_deactivate,
+review_sla_hours, sla_action, team_lead
+FROM team_settings
+WHERE team_name = $1`, teamName).Scan(&settings.AssignmentStrategy, &settings.ReviewersCount, &settings.MaxOpenReviews, &settings.RequiredApprovals,
+		&settings.ReassignOnDeactivate, &settings.ReviewSLAHours, &settings.SLAAction, &settings.TeamLead)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return models.TeamSettings{}, err
+	}
+
+	rows, err := r.DB.Query(ctx, `SELECT fallback_team
+FROM team_fallbacks
+WHERE team_name = $1
+ORDER BY priority`, teamName)
+	if err != nil {
+		return models.TeamSettings{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fallbackTeam string
+		if err = rows.Scan(&fallbackTeam); err != nil {
+			return models.TeamSettings{}, err
+		}
+		settings.FallbackTeams = append(settings.FallbackTeams, fallbackTeam)
+	}
+	return settings, rows.Err()
+}
+
+func (r *TeamRepository) LockTeamSettings(ctx context.Context, tx pgx.Tx, teamName string) (models.TeamSettings, error) {
+	_, err := tx.Exec(ctx, `INSERT INTO team_settings (team_name) VALUES ($1) ON CONFLICT (team_name) DO NOTHING`, teamName)
+	if err != nil {
+		return models.TeamSettings{}, err
+	}
+
+	settings := models.TeamSettings{TeamName: teamName}
+	err = tx.QueryRow(ctx, `SELECT assignment_strategy, reviewers_count, max_open_reviews, required_approvals, reassign_on_deactivate,
+review_sla_hours, sla_action, team_lead
+FROM team_settings
+WHERE team_name = $1
+FOR UPDATE`, teamName).Scan(&settings.AssignmentStrategy, &settings.ReviewersCount, &settings.MaxOpenReviews, &settings.RequiredApprovals,
+		&settings.ReassignOnDeactivate, &settings.ReviewSLAHours, &settings.SLAAction, &settings.TeamLead)
+	if err != nil {
+		return models.TeamSettings{}, err
+	}
+
+	rows, err := tx.Query(ctx, `SELECT fallback_team
+FROM team_fallbacks
+WHERE team_name = $1
+ORDER BY priority`, teamName)
+	if err != nil {
+		return models.TeamSettings{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var fallbackTeam string
+		if err = rows.Scan(&fallbackTeam); err != nil {
+			return models.TeamSettings{}, err
+		}
+		settings.FallbackTeams = append(settings.FallbackTeams, fallbackTeam)
+	}
+	return settings, rows.Err()
+}
+
+func (r *TeamRepository) UpsertTeamSettings(ctx context.Context, tx pgx.Tx, settings models.TeamSettings) (models.TeamSettings, error) {
+	var saved models.TeamSettings
+	err := tx.QueryRow(ctx, `INSERT INTO team_settings (team_name, assignment_strategy, reviewers_count, max_open_reviews, required_approvals,
+reassign_on_deactivate, review_sla_hours, sla_action, team_lead)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (team_name) DO UPDATE SET assignment_strategy = EXCLUDED.assignment_strategy,
+reviewers_count = EXCLUDED.reviewers_count, max_open_reviews = EXCLUDED.max_open_reviews,
+required_approvals = EXCLUDED.required_approvals, reassign_on_deactivate = EXCLUDED.reassign_on_deactivate,
+review_sla_hours = EXCLUDED.review_sla_hours, sla_action = EXCLUDED.sla_action, team_lead = EXCLUDED.team_lead
+RETURNING team_name, assignment_strategy, reviewers_count, max_open_reviews, required_approvals, reassign_on_deactivate,
+review_sla_hours, sla_action, team_lead`, settings.TeamName,
+		settings.AssignmentStrategy, settings.ReviewersCount, settings.MaxOpenReviews, settings.RequiredApprovals,
+		settings.ReassignOnDeactivate, settings.ReviewSLAHours, settings.SLAAction,
+		settings.TeamLead).Scan(&saved.TeamName, &saved.AssignmentStrategy, &saved.ReviewersCount, &saved.MaxOpenReviews,
+		&saved.RequiredApprovals, &saved.ReassignOnDeactivate, &saved.ReviewSLAHours, &saved.SLAAction, &saved.TeamLead)
+	if err != nil {
+		return models.TeamSettings{}, err
+	}
+	return saved, nil
+}
+
+func (r *TeamRepository) ReplaceFallbackTeams(ctx context.Context, tx pgx.Tx, teamName string, fallbackTeams []string) error {
+	_, err := tx.Exec(ctx, `DELETE FROM team_fallbacks WHERE team_name = $1`, teamName)
+	if err != nil {
+		return err
+	}
+
+	for priority, fallbackTeam := range fallbackTeams {
+		_, err = tx.Exec(ctx, `INSERT INTO team_fallbacks (team_name, fallback_team, priority)
+VALUES ($1, $2, $3)`, teamName, fallbackTeam, priority)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *TeamRepository) LockRoundRobinCursor(ctx context.Context, tx pgx.Tx, teamName string) (string, error) {
+	var lastUserID string
+	err := tx.QueryRow(ctx, `INSERT INTO round_robin_cursors (team_name)
+VALUES ($1)
+ON CONFLICT (team_name) DO UPDATE SET team_name = EXCLUDED.team_name
+RETURNING last_user_id`, teamName).Scan(&lastUserID)
+	if err != nil {
+		return "", err
+	}
+	return lastUserID, nil
+}
+
+func (r *TeamRepository) GetRoundRobinCursor(ctx context.Context, teamName string) (string, error) {
+	var lastUserID string
+	err := r.DB.QueryRow(ctx, `SELECT last_user_id FROM round_robin_cursors WHERE team_name = $1`, teamName).Scan(&lastUserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	return lastUserID, nil
+}
+
+func (r *TeamRepository) SaveRoundRobinCursor(ctx context.Context, tx pgx.Tx, teamName, lastUserID string) error {
+	_, err := tx.Exec(ctx, `UPDATE round_robin_cursors SET last_user_id = $1 WHERE team_name = $2`, lastUserID, teamName)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *TeamRepository) GetCodeOwners(ctx context.Context, teamName string) (string, error) {
+	var content string
+	err := r.DB.QueryRow(ctx, `SELECT content FROM team_codeowners WHERE team_name = $1`, teamName).Scan(&content)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	return content, nil
+}
+
+func (r *TeamRepository) UpsertCodeOwners(ctx context.Context, teamName, content string) error {
+	_, err := r.DB.Exec(ctx, `INSERT INTO team_codeowners (team_name, content)
+VALUES ($1, $2)
+ON CONFLICT (team_name) DO UPDATE SET content = EXCLUDED.content`, teamName, content)
+	if err != nil {
+		return err
+	}
+	return nil
+}
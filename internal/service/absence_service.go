@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"pull-request-reviewers-service/internal/models"
+	"pull-request-reviewers-service/internal/repository"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type AbsenceService struct {
+	r         *repository.AbsenceRepository
+	prRepo    *repository.PullRequestRepository
+	prService *PullRequestService
+}
+
+func NewAbsenceService(r *repository.AbsenceRepository, prRepo *repository.PullRequestRepository, prService *PullRequestService) *AbsenceService {
+	return &AbsenceService{r, prRepo, prService}
+}
+
+func (s *AbsenceService) CreateAbsence(ctx context.Context, absence models.Absence) (models.Absence, error) {
+	if !absence.EndsAt.After(absence.StartsAt) {
+		return models.Absence{}, models.ErrInvalidAbsencePeriod
+	}
+
+	created, err := s.r.CreateAbsence(ctx, absence)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return models.Absence{}, models.ErrUserNotFound
+		}
+		return models.Absence{}, err
+	}
+	return created, nil
+}
+
+func (s *AbsenceService) GetAbsences(ctx context.Context, userID string) ([]models.Absence, error) {
+	return s.r.GetAbsences(ctx, userID)
+}
+
+func (s *AbsenceService) DeleteAbsence(ctx context.Context, absenceID int64) error {
+	err := s.r.DeleteAbsence(ctx, absenceID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return models.ErrAbsenceNotFound
+	}
+	return err
+}
+
+func (s *AbsenceService) RunAutoReassign(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.reassignAbsentReviewers(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *AbsenceService) reassignAbsentReviewers(ctx context.Context) {
+	absences, err := s.r.GetStartedAbsences(ctx)
+	if err != nil {
+		log.Printf("absence worker: started absences: %v", err)
+		return
+	}
+
+	for _, absence := range absences {
+		if err = s.reassignAbsence(ctx, absence); err != nil {
+			log.Printf("absence worker: reassign reviews of %s: %v", absence.UserID, err)
+		}
+	}
+}
+
+func (s *AbsenceService) reassignAbsence(ctx context.Context, absence models.Absence) error {
+	tx, err := s.prRepo.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	locked, err := s.r.LockStartedAbsence(ctx, tx, absence.Id)
+	if err != nil || !locked {
+		return err
+	}
+
+	report, err := s.prService.reassignOpenReviews(ctx, tx, absence.UserID)
+	if err != nil {
+		return err
+	}
+
+	var unresolved []string
+	for _, result := range report {
+		if len(result.UnresolvedSlots) > 0 {
+			unresolved = append(unresolved, result.PullRequestID)
+		}
+	}
+	if len(unresolved) == 0 {
+		if err = s.r.MarkAbsenceReassigned(ctx, tx, absence.Id); err != nil {
+			return err
+		}
+	} else {
+		log.Printf("absence worker: no replacement for %s in %v, will retry", absence.UserID, unresolved)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+	observeReassignments(models.AssignmentActionReassign, report, false)
+	return nil
+}
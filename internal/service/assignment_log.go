@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"pull-request-reviewers-service/internal/models"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *PullRequestService) recordDecision(ctx context.Context, tx pgx.Tx, pullRequest models.PullRequest, action, replacedReviewer string,
+	settings models.TeamSettings, plan assignmentPlan) error {
+	members, err := s.r.GetMembersAvailability(ctx, tx, append(slices.Clone(plan.teams), plan.unusedTeams...), pullRequest.Id)
+	if err != nil {
+		return err
+	}
+	return s.r.AddAssignmentDecision(ctx, tx, newDecision(pullRequest, action, replacedReviewer, settings, plan, members))
+}
+
+func newDecision(pullRequest models.PullRequest, action, replacedReviewer string, settings models.TeamSettings,
+	plan assignmentPlan, members []models.MemberAvailability) models.AssignmentDecision {
+	decision := models.AssignmentDecision{
+		PullRequestID:    pullRequest.Id,
+		Action:           action,
+		Strategy:         settings.AssignmentStrategy,
+		ReplacedReviewer: replacedReviewer,
+		Candidates:       plan.candidates,
+		Excluded:         []models.ExcludedUser{},
+		Selected:         make([]models.SelectedReviewer, 0, len(plan.reviewers)),
+		DecidedAt:        time.Now(),
+	}
+	if decision.Candidates == nil {
+		decision.Candidates = []models.ReviewerCandidate{}
+	}
+
+	for _, member := range members {
+		if slices.ContainsFunc(plan.candidates, func(c models.ReviewerCandidate) bool { return c.UserID == member.UserID }) {
+			continue
+		}
+		decision.Excluded = append(decision.Excluded, models.ExcludedUser{
+			UserID:   member.UserID,
+			TeamName: member.TeamName,
+			Reason:   exclusionReason(member, pullRequest.AuthorID, plan.unusedTeams),
+		})
+	}
+
+	for _, reviewerID := range plan.reviewers {
+		decision.Selected = append(decision.Selected, models.SelectedReviewer{UserID: reviewerID, Rule: plan.rules[reviewerID]})
+	}
+	return decision
+}
+
+func exclusionReason(member models.MemberAvailability, authorID string, unusedTeams []string) string {
+	switch {
+	case member.UserID == authorID:
+		return models.ExclusionAuthor
+	case !member.IsActive:
+		return models.ExclusionInactive
+	case member.Absent:
+		return models.ExclusionAbsent
+	case member.AlreadyAssigned:
+		return models.ExclusionAlreadyAssigned
+	case member.MaxOpenReviews != nil && member.OpenReviews >= *member.MaxOpenReviews:
+		return models.ExclusionAtCapacity
+	case slices.Contains(unusedTeams, member.TeamName):
+		return models.ExclusionFallbackUnused
+	default:
+		return models.ExclusionOther
+	}
+}
+
+func (s *PullRequestService) GetAssignmentLog(ctx context.Context, prID string) ([]models.AssignmentDecision, error) {
+	exist, err := s.r.PullRequestExists(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, models.ErrPullRequestNotFound
+	}
+	return s.r.GetAssignmentDecisions(ctx, prID)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"math/rand/v2"
+	"pull-request-reviewers-service/internal/models"
+	"pull-request-reviewers-service/internal/repository"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type ReviewerAssignmentStrategy interface {
+	Rank(candidates []models.ReviewerCandidate, lastUserID string) []models.ReviewerCandidate
+}
+
+func newAssignmentStrategies() map[string]ReviewerAssignmentStrategy {
+	return map[string]ReviewerAssignmentStrategy{
+		models.StrategyRandom:      randomStrategy{},
+		models.StrategyRoundRobin:  roundRobinStrategy{},
+		models.StrategyLeastLoaded: leastLoadedStrategy{},
+		models.StrategyWeighted:    weightedStrategy{},
+	}
+}
+
+type randomStrategy struct{}
+
+func (randomStrategy) Rank(candidates []models.ReviewerCandidate, _ string) []models.ReviewerCandidate {
+	return shuffleCandidates(candidates)
+}
+
+type leastLoadedStrategy struct{}
+
+func (leastLoadedStrategy) Rank(candidates []models.ReviewerCandidate, _ string) []models.ReviewerCandidate {
+	shuffled := shuffleCandidates(candidates)
+	slices.SortStableFunc(shuffled, func(a, b models.ReviewerCandidate) int {
+		return a.OpenReviews - b.OpenReviews
+	})
+	return shuffled
+}
+
+type weightedStrategy struct{}
+
+func (weightedStrategy) Rank(candidates []models.ReviewerCandidate, _ string) []models.ReviewerCandidate {
+	pool := slices.Clone(candidates)
+	ranked := make([]models.ReviewerCandidate, 0, len(pool))
+	for len(pool) > 0 {
+		var total float64
+		for _, candidate := range pool {
+			total += candidateWeight(candidate)
+		}
+
+		target := rand.Float64() * total
+		chosen := len(pool) - 1
+		for i, candidate := range pool {
+			target -= candidateWeight(candidate)
+			if target < 0 {
+				chosen = i
+				break
+			}
+		}
+		ranked = append(ranked, pool[chosen])
+		pool = slices.Delete(pool, chosen, chosen+1)
+	}
+	return ranked
+}
+
+func candidateWeight(candidate models.ReviewerCandidate) float64 {
+	return 1 / float64(1+candidate.OpenReviews)
+}
+
+type roundRobinStrategy struct{}
+
+func (roundRobinStrategy) Rank(candidates []models.ReviewerCandidate, lastUserID string) []models.ReviewerCandidate {
+	ordered := slices.Clone(candidates)
+	slices.SortFunc(ordered, func(a, b models.ReviewerCandidate) int {
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	start := slices.IndexFunc(ordered, func(candidate models.ReviewerCandidate) bool {
+		return candidate.UserID > lastUserID
+	})
+	if start <= 0 {
+		return ordered
+	}
+	return append(ordered[start:], ordered[:start]...)
+}
+
+type roundRobinCursors struct {
+	teamRepo *repository.TeamRepository
+	tx       pgx.Tx
+	enabled  bool
+	readOnly bool
+	initial  map[string]string
+	last     map[string]string
+	changed  map[string]bool
+}
+
+func newRoundRobinCursors(teamRepo *repository.TeamRepository, tx pgx.Tx, strategy string, readOnly bool) *roundRobinCursors {
+	return &roundRobinCursors{
+		teamRepo: teamRepo,
+		tx:       tx,
+		enabled:  strategy == models.StrategyRoundRobin,
+		readOnly: readOnly,
+		initial:  make(map[string]string),
+		last:     make(map[string]string),
+		changed:  make(map[string]bool),
+	}
+}
+
+func (c *roundRobinCursors) load(ctx context.Context, teamName string) error {
+	if _, ok := c.initial[teamName]; ok || !c.enabled {
+		return nil
+	}
+
+	var lastUserID string
+	var err error
+	if c.readOnly {
+		lastUserID, err = c.teamRepo.GetRoundRobinCursor(ctx, teamName)
+	} else {
+		lastUserID, err = c.teamRepo.LockRoundRobinCursor(ctx, c.tx, teamName)
+	}
+	if err != nil {
+		return err
+	}
+	c.initial[teamName] = lastUserID
+	c.last[teamName] = lastUserID
+	return nil
+}
+
+func (c *roundRobinCursors) get(ctx context.Context, teamName string) (string, error) {
+	err := c.load(ctx, teamName)
+	return c.last[teamName], err
+}
+
+func (c *roundRobinCursors) start(ctx context.Context, teamName string) (string, error) {
+	err := c.load(ctx, teamName)
+	return c.initial[teamName], err
+}
+
+func (c *roundRobinCursors) advance(teamName, userID string) {
+	if !c.enabled {
+		return
+	}
+	c.last[teamName] = userID
+	c.changed[teamName] = true
+}
+
+func (c *roundRobinCursors) rebase() {
+	maps.Copy(c.initial, c.last)
+}
+
+func (c *roundRobinCursors) save(ctx context.Context) error {
+	if c.readOnly {
+		return nil
+	}
+	for teamName := range c.changed {
+		if err := c.teamRepo.SaveRoundRobinCursor(ctx, c.tx, teamName, c.last[teamName]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func shuffleCandidates(candidates []models.ReviewerCandidate) []models.ReviewerCandidate {
+	shuffled := slices.Clone(candidates)
+	rand.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return shuffled
+}
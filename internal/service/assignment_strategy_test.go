@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"pull-request-reviewers-service/internal/models"
+	"slices"
+	"testing"
+	"time"
+)
+
+func candidatesOf(userIDs ...string) []models.ReviewerCandidate {
+	candidates := make([]models.ReviewerCandidate, 0, len(userIDs))
+	for _, userID := range userIDs {
+		candidates = append(candidates, models.ReviewerCandidate{UserID: userID, TeamName: "backend"})
+	}
+	return candidates
+}
+
+func userIDsOf(candidates []models.ReviewerCandidate) []string {
+	userIDs := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		userIDs = append(userIDs, candidate.UserID)
+	}
+	return userIDs
+}
+
+func seededCursors(strategy string, last map[string]string) *roundRobinCursors {
+	cursors := newRoundRobinCursors(nil, nil, strategy, true)
+	for teamName, lastUserID := range last {
+		cursors.initial[teamName] = lastUserID
+		cursors.last[teamName] = lastUserID
+	}
+	return cursors
+}
+
+func TestRoundRobinRank(t *testing.T) {
+	tests := []struct {
+		name       string
+		candidates []string
+		lastUserID string
+		want       []string
+	}{
+		{name: "no cursor", candidates: []string{"u3", "u1", "u2"}, lastUserID: "", want: []string{"u1", "u2", "u3"}},
+		{name: "continues after cursor", candidates: []string{"u1", "u2", "u3"}, lastUserID: "u1", want: []string{"u2", "u3", "u1"}},
+		{name: "wraps after last member", candidates: []string{"u1", "u2", "u3"}, lastUserID: "u3", want: []string{"u1", "u2", "u3"}},
+		{name: "cursor past every member", candidates: []string{"u1", "u2"}, lastUserID: "u9", want: []string{"u1", "u2"}},
+		{name: "skips inactive cursor member", candidates: []string{"u1", "u3", "u4"}, lastUserID: "u2", want: []string{"u3", "u4", "u1"}},
+		{name: "skips inactive next member", candidates: []string{"u1", "u2", "u4"}, lastUserID: "u2", want: []string{"u4", "u1", "u2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := userIDsOf(roundRobinStrategy{}.Rank(candidatesOf(tt.candidates...), tt.lastUserID))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Rank(%v, %q) = %v, want %v", tt.candidates, tt.lastUserID, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundRobinCursorAcrossAssignments(t *testing.T) {
+	service := NewPullRequestService(nil, nil)
+	settings := models.TeamSettings{AssignmentStrategy: models.StrategyRoundRobin}
+	cursors := seededCursors(models.StrategyRoundRobin, map[string]string{"backend": ""})
+	tiers := []reviewerTier{{teamName: "backend", candidates: candidatesOf("u1", "u2", "u3")}}
+
+	var got []string
+	for range 4 {
+		reviewers, _, err := service.pickReviewers(context.Background(), nil, settings, cursors, tiers,
+			assignmentRequest{count: 1, readOnly: true, now: time.Now()})
+		if err != nil {
+			t.Fatalf("pickReviewers: %v", err)
+		}
+		got = append(got, reviewers...)
+	}
+	if want := []string{"u1", "u2", "u3", "u1"}; !slices.Equal(got, want) {
+		t.Errorf("consecutive assignments = %v, want %v", got, want)
+	}
+}
+
+func TestRoundRobinCursorAcrossTiers(t *testing.T) {
+	tests := []struct {
+		name       string
+		tiers      [][]string
+		lastUserID string
+		count      int
+		want       []string
+		wantCursor string
+	}{
+		{
+			name:       "codeowner tier first, rest continues after it",
+			tiers:      [][]string{{"u3"}, {"u1", "u2", "u4"}},
+			lastUserID: "u1",
+			count:      2,
+			want:       []string{"u3", "u4"},
+			wantCursor: "u4",
+		},
+		{
+			name:       "second tier wraps",
+			tiers:      [][]string{{"u4"}, {"u1", "u2", "u3"}},
+			lastUserID: "u2",
+			count:      3,
+			want:       []string{"u4", "u1", "u2"},
+			wantCursor: "u2",
+		},
+		{
+			name:       "single tier advances once per pick",
+			tiers:      [][]string{{"u1", "u2", "u3"}},
+			lastUserID: "u2",
+			count:      2,
+			want:       []string{"u3", "u1"},
+			wantCursor: "u1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewPullRequestService(nil, nil)
+			settings := models.TeamSettings{AssignmentStrategy: models.StrategyRoundRobin}
+			cursors := seededCursors(models.StrategyRoundRobin, map[string]string{"backend": tt.lastUserID})
+			var tiers []reviewerTier
+			for _, userIDs := range tt.tiers {
+				tiers = append(tiers, reviewerTier{teamName: "backend", candidates: candidatesOf(userIDs...)})
+			}
+
+			got, _, err := service.pickReviewers(context.Background(), nil, settings, cursors, tiers,
+				assignmentRequest{count: tt.count, readOnly: true, now: time.Now()})
+			if err != nil {
+				t.Fatalf("pickReviewers: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("reviewers = %v, want %v", got, tt.want)
+			}
+			if cursors.last["backend"] != tt.wantCursor {
+				t.Errorf("cursor = %q, want %q", cursors.last["backend"], tt.wantCursor)
+			}
+		})
+	}
+}
+
+func TestLeastLoadedRank(t *testing.T) {
+	candidates := []models.ReviewerCandidate{
+		{UserID: "busy", OpenReviews: 3},
+		{UserID: "idle1", OpenReviews: 0},
+		{UserID: "some", OpenReviews: 1},
+		{UserID: "idle2", OpenReviews: 0},
+	}
+
+	firsts := make(map[string]int)
+	for range 200 {
+		ranked := leastLoadedStrategy{}.Rank(candidates, "")
+		if !slices.IsSortedFunc(ranked, func(a, b models.ReviewerCandidate) int { return a.OpenReviews - b.OpenReviews }) {
+			t.Fatalf("Rank() = %v, not ordered by open reviews", userIDsOf(ranked))
+		}
+		if got := userIDsOf(ranked[2:]); !slices.Equal(got, []string{"some", "busy"}) {
+			t.Fatalf("Rank() tail = %v, want [some busy]", got)
+		}
+		firsts[ranked[0].UserID]++
+	}
+	if firsts["idle1"] == 0 || firsts["idle2"] == 0 {
+		t.Errorf("tied candidates were not both ranked first: %v", firsts)
+	}
+}
+
+func TestWeightedRankDistribution(t *testing.T) {
+	candidates := []models.ReviewerCandidate{
+		{UserID: "idle", OpenReviews: 0},
+		{UserID: "loaded", OpenReviews: 1},
+		{UserID: "busy", OpenReviews: 3},
+	}
+	const runs = 20000
+	firsts := make(map[string]int)
+	for range runs {
+		ranked := weightedStrategy{}.Rank(candidates, "")
+		if len(ranked) != len(candidates) {
+			t.Fatalf("Rank() returned %d candidates, want %d", len(ranked), len(candidates))
+		}
+		firsts[ranked[0].UserID]++
+	}
+
+	want := map[string]float64{"idle": 4.0 / 7, "loaded": 2.0 / 7, "busy": 1.0 / 7}
+	for userID, share := range want {
+		got := float64(firsts[userID]) / runs
+		if got < share-0.03 || got > share+0.03 {
+			t.Errorf("%s ranked first in %.3f of runs, want about %.3f", userID, got, share)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"pull-request-reviewers-service/internal/models"
+	"regexp"
+	"strings"
+)
+
+type codeOwnersMatcher struct {
+	rules    []models.CodeOwnersRule
+	patterns []*regexp.Regexp
+}
+
+func parseCodeOwners(content string) (codeOwnersMatcher, error) {
+	var matcher codeOwnersMatcher
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		pattern := fields[0]
+		if strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "[]") {
+			return codeOwnersMatcher{}, fmt.Errorf("%w: unsupported pattern %q on line %d", models.ErrInvalidCodeOwners, pattern, lineNum)
+		}
+
+		re, err := regexp.Compile(codeOwnersPatternToRegexp(pattern))
+		if err != nil {
+			return codeOwnersMatcher{}, fmt.Errorf("%w: bad pattern %q on line %d", models.ErrInvalidCodeOwners, pattern, lineNum)
+		}
+
+		owners := make([]string, 0, len(fields)-1)
+		for _, owner := range fields[1:] {
+			owners = append(owners, strings.TrimPrefix(owner, "@"))
+		}
+		matcher.rules = append(matcher.rules, models.CodeOwnersRule{Pattern: pattern, Owners: owners})
+		matcher.patterns = append(matcher.patterns, re)
+	}
+	if err := scanner.Err(); err != nil {
+		return codeOwnersMatcher{}, err
+	}
+	return matcher, nil
+}
+
+func (m codeOwnersMatcher) Owners(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(m.patterns) - 1; i >= 0; i-- {
+		if m.patterns[i].MatchString(path) {
+			return m.rules[i].Owners
+		}
+	}
+	return nil
+}
+
+func (m codeOwnersMatcher) OwnersOf(paths []string) map[string]bool {
+	owners := make(map[string]bool)
+	for _, path := range paths {
+		for _, owner := range m.Owners(path) {
+			owners[owner] = true
+		}
+	}
+	return owners
+}
+
+func codeOwnersPatternToRegexp(pattern string) string {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			b.WriteString("[^/]*")
+		case pattern[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+
+	lastSegment := pattern[strings.LastIndex(pattern, "/")+1:]
+	switch {
+	case dirOnly:
+		b.WriteString("/.*$")
+	case strings.Contains(lastSegment, "*"):
+		b.WriteString("$")
+	default:
+		b.WriteString("(?:/.*)?$")
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"pull-request-reviewers-service/internal/models"
+	"slices"
+	"testing"
+)
+
+func TestCodeOwnersOwners(t *testing.T) {
+	const content = `# default owners
+*            @lead
+*.go         @gopher
+/docs/       @writer
+api/         @backend
+web/static   @frontend
+build/logs/  @ops # generated
+/internal/*  @core
+**/testdata  @qa
+`
+	matcher, err := parseCodeOwners(content)
+	if err != nil {
+		t.Fatalf("parseCodeOwners: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{name: "catch-all", path: "README.md", want: []string{"lead"}},
+		{name: "extension anywhere", path: "cmd/main.go", want: []string{"gopher"}},
+		{name: "leading slash in path", path: "/cmd/main.go", want: []string{"gopher"}},
+		{name: "later rule wins over extension", path: "docs/guide.go", want: []string{"writer"}},
+		{name: "anchored directory", path: "docs/img/logo.png", want: []string{"writer"}},
+		{name: "anchored directory not nested", path: "site/docs/index.md", want: []string{"lead"}},
+		{name: "trailing slash only matches anywhere", path: "pkg/api/handler.yaml", want: []string{"backend"}},
+		{name: "inner slash anchors", path: "web/static/app.css", want: []string{"frontend"}},
+		{name: "inner slash not matched deeper", path: "cmd/web/static/app.css", want: []string{"lead"}},
+		{name: "comment stripped", path: "build/logs/out.txt", want: []string{"ops"}},
+		{name: "single star stays in directory", path: "internal/service.yaml", want: []string{"core"}},
+		{name: "single star does not cross slash", path: "internal/service/x.yaml", want: []string{"lead"}},
+		{name: "double star prefix", path: "a/b/testdata/case.json", want: []string{"qa"}},
+		{name: "double star at root", path: "testdata/case.json", want: []string{"qa"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Owners(tt.path); !slices.Equal(got, tt.want) {
+				t.Errorf("Owners(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodeOwnersOwnersNoMatch(t *testing.T) {
+	matcher, err := parseCodeOwners("/src/ @dev\n")
+	if err != nil {
+		t.Fatalf("parseCodeOwners: %v", err)
+	}
+	if got := matcher.Owners("lib/src/a.go"); got != nil {
+		t.Errorf("Owners = %v, want nil", got)
+	}
+}
+
+func TestParseCodeOwnersUnsupported(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "negation", content: "!*.go @dev"},
+		{name: "character class", content: "*.[ch] @dev"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseCodeOwners(tt.content); !errors.Is(err, models.ErrInvalidCodeOwners) {
+				t.Errorf("err = %v, want ErrInvalidCodeOwners", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"pull-request-reviewers-service/internal/metrics"
+	"pull-request-reviewers-service/internal/models"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *PullRequestService) reassignReleasedReviews(ctx context.Context, tx pgx.Tx, reviewerIDs []string) ([]models.PullRequestReassignment, error) {
+	approved, err := s.r.GetApprovedOpenReviews(ctx, tx, reviewerIDs)
+	if err != nil {
+		return nil, err
+	}
+	released, err := s.r.ReleaseOpenReviews(ctx, tx, reviewerIDs, models.UnassignDeactivate)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(released, func(a, b models.ReleasedReview) int {
+		return cmp.Or(cmp.Compare(a.PullRequestID, b.PullRequestID), cmp.Compare(a.ReviewerID, b.ReviewerID))
+	})
+
+	batch, err := s.loadRefillBatch(ctx, tx, released)
+	if err != nil {
+		return nil, err
+	}
+
+	report := []models.PullRequestReassignment{}
+	var assignments []models.ReviewerAssignment
+	var decisions []models.AssignmentDecision
+	for _, review := range released {
+		if len(report) == 0 || report[len(report)-1].PullRequestID != review.PullRequestID {
+			report = append(report, models.PullRequestReassignment{PullRequestID: review.PullRequestID})
+		}
+		result := &report[len(report)-1]
+
+		pullRequest := batch.pullRequests[review.PullRequestID]
+		settings := batch.settings[review.TeamName]
+		plan, err := s.planAssignment(ctx, tx, settings, assignmentRequest{
+			teamName:     review.TeamName,
+			labels:       pullRequest.Labels,
+			changedFiles: pullRequest.ChangedFiles,
+			count:        1,
+			now:          time.Now(),
+			reserved:     true,
+			cursors:      batch.cursors[review.TeamName],
+			codeOwners:   batch.codeOwners,
+			find: func(teamName string) ([]models.ReviewerCandidate, error) {
+				return batch.candidates(teamName, pullRequest), nil
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		batch.cursors[review.TeamName].rebase()
+		if len(plan.reviewers) == 0 {
+			result.UnresolvedSlots = append(result.UnresolvedSlots, review.ReviewerID)
+			continue
+		}
+
+		members := batch.availability(append(slices.Clone(plan.teams), plan.unusedTeams...), pullRequest)
+		decisions = append(decisions, newDecision(pullRequest, models.AssignmentActionDeactivate, review.ReviewerID, settings, plan, members))
+		assignments = append(assignments, models.ReviewerAssignment{PullRequestID: pullRequest.Id, ReviewerID: plan.reviewers[0]})
+		batch.assign(pullRequest.Id, plan.reviewers[0])
+		result.Replacements = append(result.Replacements, models.ReviewerReplacement{
+			OldReviewerID: review.ReviewerID,
+			NewReviewerID: plan.reviewers[0],
+		})
+	}
+
+	if len(assignments) > 0 {
+		if err = s.r.AddReviewerAssignments(ctx, tx, assignments); err != nil {
+			return nil, err
+		}
+		if err = s.r.AddAssignmentDecisions(ctx, tx, decisions); err != nil {
+			return nil, err
+		}
+	}
+	for _, cursors := range batch.cursors {
+		if err = cursors.save(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	for prID, reviewers := range approved {
+		i := slices.IndexFunc(report, func(result models.PullRequestReassignment) bool { return result.PullRequestID == prID })
+		if i < 0 {
+			report = append(report, models.PullRequestReassignment{PullRequestID: prID})
+			i = len(report) - 1
+		}
+		report[i].KeptApprovals = reviewers
+	}
+	slices.SortFunc(report, func(a, b models.PullRequestReassignment) int {
+		return cmp.Compare(a.PullRequestID, b.PullRequestID)
+	})
+
+	var events []models.PullRequestEvent
+	for _, result := range report {
+		for _, replacement := range result.Replacements {
+			events = append(events, newEvent(result.PullRequestID, models.EventReviewerReplaced, replacement.OldReviewerID, replacement.NewReviewerID))
+		}
+		events = append(events, reviewerEvents(result.PullRequestID, models.EventReviewerRemoved, result.UnresolvedSlots)...)
+	}
+	if err = s.recordEvents(ctx, tx, events...); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+type refillBatch struct {
+	pullRequests map[string]models.PullRequest
+	settings     map[string]models.TeamSettings
+	members      map[string][]models.MemberAvailability
+	cursors      map[string]*roundRobinCursors
+	codeOwners   map[string]codeOwnersMatcher
+}
+
+func (s *PullRequestService) loadRefillBatch(ctx context.Context, tx pgx.Tx, released []models.ReleasedReview) (refillBatch, error) {
+	batch := refillBatch{
+		settings:   make(map[string]models.TeamSettings),
+		members:    make(map[string][]models.MemberAvailability),
+		cursors:    make(map[string]*roundRobinCursors),
+		codeOwners: make(map[string]codeOwnersMatcher),
+	}
+
+	var prIDs, teamNames []string
+	for _, review := range released {
+		if !slices.Contains(prIDs, review.PullRequestID) {
+			prIDs = append(prIDs, review.PullRequestID)
+		}
+		if _, ok := batch.settings[review.TeamName]; ok {
+			continue
+		}
+		settings, err := s.teamRepo.GetTeamSettings(ctx, review.TeamName)
+		if err != nil {
+			return refillBatch{}, err
+		}
+		batch.settings[review.TeamName] = settings
+		batch.cursors[review.TeamName] = newRoundRobinCursors(s.teamRepo, tx, settings.AssignmentStrategy, false)
+		for _, teamName := range append([]string{review.TeamName}, settings.FallbackTeams...) {
+			if !slices.Contains(teamNames, teamName) {
+				teamNames = append(teamNames, teamName)
+			}
+		}
+	}
+	if len(released) == 0 {
+		return batch, nil
+	}
+
+	var err error
+	if batch.pullRequests, err = s.r.GetPullRequestsWithReviewers(ctx, tx, prIDs); err != nil {
+		return refillBatch{}, err
+	}
+	if err = s.r.LockTeamMembers(ctx, tx, teamNames); err != nil {
+		return refillBatch{}, err
+	}
+	for _, teamName := range teamNames {
+		if batch.members[teamName], err = s.r.GetTeamAvailability(ctx, tx, teamName); err != nil {
+			return refillBatch{}, err
+		}
+	}
+	return batch, nil
+}
+
+func (b refillBatch) candidates(teamName string, pullRequest models.PullRequest) []models.ReviewerCandidate {
+	var candidates []models.ReviewerCandidate
+	for _, member := range b.members[teamName] {
+		if !member.IsActive || member.Absent || member.UserID == pullRequest.AuthorID ||
+			slices.Contains(pullRequest.AssignedReviewers, member.UserID) ||
+			member.MaxOpenReviews != nil && member.OpenReviews >= *member.MaxOpenReviews {
+			continue
+		}
+		candidates = append(candidates, models.ReviewerCandidate{
+			UserID:      member.UserID,
+			TeamName:    member.TeamName,
+			OpenReviews: member.OpenReviews,
+			Skills:      member.Skills,
+			TimeZone:    member.TimeZone,
+			WorkStart:   member.WorkStart,
+			WorkEnd:     member.WorkEnd,
+		})
+	}
+	return candidates
+}
+
+func (b refillBatch) availability(teamNames []string, pullRequest models.PullRequest) []models.MemberAvailability {
+	var members []models.MemberAvailability
+	for _, teamName := range teamNames {
+		for _, member := range b.members[teamName] {
+			member.AlreadyAssigned = slices.Contains(pullRequest.AssignedReviewers, member.UserID)
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+func (b refillBatch) assign(prID, reviewerID string) {
+	pullRequest := b.pullRequests[prID]
+	pullRequest.AssignedReviewers = append(pullRequest.AssignedReviewers, reviewerID)
+	b.pullRequests[prID] = pullRequest
+
+	for teamName, members := range b.members {
+		for i := range members {
+			if members[i].UserID == reviewerID {
+				b.members[teamName][i].OpenReviews++
+			}
+		}
+	}
+}
+
+func (s *PullRequestService) reassignOpenReviews(ctx context.Context, tx pgx.Tx, reviewerID string) ([]models.PullRequestReassignment, error) {
+	prIDs, err := s.r.GetOpenPRsByReviewer(ctx, tx, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := make([]models.PullRequestReassignment, 0, len(prIDs))
+	for _, prID := range prIDs {
+		result := models.PullRequestReassignment{PullRequestID: prID}
+		newReviewerID, err := s.replaceReviewer(ctx, tx, prID, reviewerID, models.AssignmentActionReassign)
+		switch {
+		case errors.Is(err, models.ErrNotEnoughMembersInTeam):
+			result.UnresolvedSlots = []string{reviewerID}
+		case err != nil:
+			return nil, err
+		default:
+			result.Replacements = []models.ReviewerReplacement{{OldReviewerID: reviewerID, NewReviewerID: newReviewerID}}
+		}
+		report = append(report, result)
+	}
+	return report, nil
+}
+
+func observeReassignments(action string, report []models.PullRequestReassignment, countUnresolved bool) {
+	for _, result := range report {
+		for range result.Replacements {
+			metrics.ObserveAssignment(action, 1, 1)
+		}
+		if countUnresolved {
+			for range result.UnresolvedSlots {
+				metrics.ObserveAssignment(action, 1, 0)
+			}
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"pull-request-reviewers-service/internal/models"
+	"slices"
+	"testing"
+)
+
+func TestRefillBatchCandidates(t *testing.T) {
+	batch := refillBatch{
+		pullRequests: map[string]models.PullRequest{
+			"pr1": {Id: "pr1", AuthorID: "author", AssignedReviewers: []string{"assigned"}},
+			"pr2": {Id: "pr2", AuthorID: "author"},
+		},
+		members: map[string][]models.MemberAvailability{
+			"backend": {
+				{UserID: "assigned", TeamName: "backend", IsActive: true},
+				{UserID: "author", TeamName: "backend", IsActive: true},
+				{UserID: "away", TeamName: "backend", IsActive: true, Absent: true},
+				{UserID: "gone", TeamName: "backend"},
+				{UserID: "limited", TeamName: "backend", IsActive: true, OpenReviews: 1, MaxOpenReviews: ptr(2)},
+				{UserID: "free", TeamName: "backend", IsActive: true},
+			},
+		},
+	}
+
+	if got := userIDsOf(batch.candidates("backend", batch.pullRequests["pr1"])); !slices.Equal(got, []string{"limited", "free"}) {
+		t.Fatalf("candidates for pr1 = %v, want [limited free]", got)
+	}
+
+	batch.assign("pr1", "limited")
+	if got := userIDsOf(batch.candidates("backend", batch.pullRequests["pr2"])); !slices.Equal(got, []string{"assigned", "free"}) {
+		t.Errorf("candidates for pr2 after filling the last slot = %v, want [assigned free]", got)
+	}
+	if got := batch.pullRequests["pr1"].AssignedReviewers; !slices.Equal(got, []string{"assigned", "limited"}) {
+		t.Errorf("pr1 reviewers = %v, want [assigned limited]", got)
+	}
+
+	members := batch.availability([]string{"backend"}, batch.pullRequests["pr1"])
+	i := slices.IndexFunc(members, func(m models.MemberAvailability) bool { return m.UserID == "limited" })
+	if !members[i].AlreadyAssigned || exclusionReason(members[i], "author", nil) != models.ExclusionAlreadyAssigned {
+		t.Errorf("limited is not reported as already assigned on pr1: %+v", members[i])
+	}
+}
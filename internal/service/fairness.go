@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pull-request-reviewers-service/internal/models"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	overloadedFactor  = 1.5
+	underloadedFactor = 0.5
+)
+
+func (s *PullRequestService) GetFairnessReport(ctx context.Context, filter models.StatsFilter) (models.FairnessResponse, error) {
+	if err := validatePeriodFilter(filter); err != nil {
+		return models.FairnessResponse{}, err
+	}
+
+	workloads, err := s.r.GetMemberWorkloads(ctx, filter)
+	if err != nil {
+		return models.FairnessResponse{}, err
+	}
+
+	report := models.FairnessResponse{Teams: make([]models.TeamFairness, 0)}
+	if filter.TeamName != "" && len(workloads) == 0 {
+		if _, err = s.teamRepo.GetTeam(ctx, filter.TeamName); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.FairnessResponse{}, models.ErrTeamNotFound
+			}
+			return models.FairnessResponse{}, err
+		}
+		report.Teams = append(report.Teams, teamFairness(filter.TeamName, []models.MemberWorkload{}))
+		return report, nil
+	}
+	for start := 0; start < len(workloads); {
+		end := start + 1
+		for end < len(workloads) && workloads[end].TeamName == workloads[start].TeamName {
+			end++
+		}
+		report.Teams = append(report.Teams, teamFairness(workloads[start].TeamName, workloads[start:end]))
+		start = end
+	}
+	return report, nil
+}
+
+func teamFairness(teamName string, members []models.MemberWorkload) models.TeamFairness {
+	fairness := models.TeamFairness{
+		TeamName:          teamName,
+		ZeroReviewMembers: make([]string, 0),
+		Members:           members,
+	}
+
+	var active []int
+	for _, member := range members {
+		fairness.TotalAssignments += member.Assignments
+		if !member.IsActive {
+			continue
+		}
+		active = append(active, member.Assignments)
+		if member.Assignments == 0 {
+			fairness.ZeroReviewMembers = append(fairness.ZeroReviewMembers, member.UserID)
+		}
+	}
+	if len(active) == 0 {
+		return fairness
+	}
+
+	fairness.Gini = gini(active)
+	if low, high := slices.Min(active), slices.Max(active); low > 0 {
+		ratio := float64(high) / float64(low)
+		fairness.MaxMinRatio = &ratio
+	}
+
+	total := 0
+	for _, count := range active {
+		total += count
+	}
+	mean := float64(total) / float64(len(active))
+	if mean == 0 {
+		return fairness
+	}
+	for i, member := range fairness.Members {
+		if !member.IsActive {
+			continue
+		}
+		switch count := float64(member.Assignments); {
+		case count > mean*overloadedFactor:
+			fairness.Members[i].Outlier = models.WorkloadOverloaded
+		case count < mean*underloadedFactor:
+			fairness.Members[i].Outlier = models.WorkloadUnderloaded
+		}
+	}
+	return fairness
+}
+
+func gini(counts []int) float64 {
+	sorted := slices.Sorted(slices.Values(counts))
+	var total, weighted float64
+	for i, count := range sorted {
+		total += float64(count)
+		weighted += float64(i+1) * float64(count)
+	}
+	if total == 0 {
+		return 0
+	}
+	n := float64(len(sorted))
+	return 2*weighted/(n*total) - (n+1)/n
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"math"
+	"pull-request-reviewers-service/internal/models"
+	"slices"
+	"testing"
+)
+
+func TestGini(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts []int
+		want   float64
+	}{
+		{name: "empty", counts: nil, want: 0},
+		{name: "all zero", counts: []int{0, 0, 0}, want: 0},
+		{name: "single member", counts: []int{5}, want: 0},
+		{name: "equal counts", counts: []int{4, 4, 4, 4}, want: 0},
+		{name: "two members", counts: []int{1, 3}, want: 0.25},
+		{name: "linear", counts: []int{1, 2, 3}, want: 2.0 / 9},
+		{name: "unsorted input", counts: []int{3, 1, 2}, want: 2.0 / 9},
+		{name: "one member takes everything", counts: []int{0, 0, 0, 10}, want: 0.75},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := gini(tt.counts); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("gini(%v) = %v, want %v", tt.counts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGiniKeepsInput(t *testing.T) {
+	counts := []int{3, 1, 2}
+	gini(counts)
+	if !slices.Equal(counts, []int{3, 1, 2}) {
+		t.Errorf("gini reordered its input: %v", counts)
+	}
+}
+
+func TestTeamFairness(t *testing.T) {
+	tests := []struct {
+		name     string
+		members  []models.MemberWorkload
+		gini     float64
+		ratio    *float64
+		zero     []string
+		outliers map[string]string
+	}{
+		{
+			name:     "no members",
+			members:  []models.MemberWorkload{},
+			zero:     []string{},
+			outliers: map[string]string{},
+		},
+		{
+			name: "all zero",
+			members: []models.MemberWorkload{
+				{UserID: "u1", IsActive: true},
+				{UserID: "u2", IsActive: true},
+			},
+			zero:     []string{"u1", "u2"},
+			outliers: map[string]string{},
+		},
+		{
+			name: "inactive members ignored",
+			members: []models.MemberWorkload{
+				{UserID: "u1", IsActive: true, Assignments: 2},
+				{UserID: "u2", IsActive: true, Assignments: 2},
+				{UserID: "u3", IsActive: false, Assignments: 0},
+			},
+			ratio:    ptr(1.0),
+			zero:     []string{},
+			outliers: map[string]string{},
+		},
+		{
+			name: "outliers",
+			members: []models.MemberWorkload{
+				{UserID: "u1", IsActive: true, Assignments: 0},
+				{UserID: "u2", IsActive: true, Assignments: 2},
+				{UserID: "u3", IsActive: true, Assignments: 10},
+			},
+			gini: 5.0 / 9,
+			zero: []string{"u1"},
+			outliers: map[string]string{
+				"u1": models.WorkloadUnderloaded,
+				"u3": models.WorkloadOverloaded,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := teamFairness("backend", slices.Clone(tt.members))
+			if math.Abs(got.Gini-tt.gini) > 1e-9 {
+				t.Errorf("Gini = %v, want %v", got.Gini, tt.gini)
+			}
+			switch {
+			case tt.ratio == nil && got.MaxMinRatio != nil:
+				t.Errorf("MaxMinRatio = %v, want nil", *got.MaxMinRatio)
+			case tt.ratio != nil && (got.MaxMinRatio == nil || *got.MaxMinRatio != *tt.ratio):
+				t.Errorf("MaxMinRatio = %v, want %v", got.MaxMinRatio, *tt.ratio)
+			}
+			if !slices.Equal(got.ZeroReviewMembers, tt.zero) {
+				t.Errorf("ZeroReviewMembers = %v, want %v", got.ZeroReviewMembers, tt.zero)
+			}
+			for _, member := range got.Members {
+				if member.Outlier != tt.outliers[member.UserID] {
+					t.Errorf("%s outlier = %q, want %q", member.UserID, member.Outlier, tt.outliers[member.UserID])
+				}
+			}
+		})
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pull-request-reviewers-service/internal/metrics"
+	"pull-request-reviewers-service/internal/models"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var statusTransitions = map[string][]string{
+	draftPullRequest:  {openPullRequest, closedPullRequest},
+	openPullRequest:   {mergedPullRequest, closedPullRequest},
+	closedPullRequest: {openPullRequest},
+}
+
+func canTransition(from, to string) bool {
+	return slices.Contains(statusTransitions[from], to)
+}
+
+func (s *PullRequestService) MarkReady(ctx context.Context, prID string) (models.PullRequest, error) {
+	return s.openPullRequest(ctx, prID, draftPullRequest, models.AssignmentActionReady)
+}
+
+func (s *PullRequestService) ReopenPullRequest(ctx context.Context, prID string) (models.PullRequest, error) {
+	return s.openPullRequest(ctx, prID, closedPullRequest, models.AssignmentActionReopen)
+}
+
+func (s *PullRequestService) ClosePullRequest(ctx context.Context, prID string) (models.PullRequest, error) {
+	tx, err := s.r.BeginTx(ctx)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	pullRequest, err := s.lockPullRequest(ctx, tx, prID)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+	if !canTransition(pullRequest.Status, closedPullRequest) {
+		return models.PullRequest{}, models.ErrInvalidStatusTransition
+	}
+
+	closedAt := time.Now()
+	if err = s.r.SetPullRequestStatus(ctx, tx, prID, closedPullRequest, &closedAt); err != nil {
+		return models.PullRequest{}, err
+	}
+	if err = s.r.RemoveReviewers(ctx, tx, prID, models.UnassignClose); err != nil {
+		return models.PullRequest{}, err
+	}
+
+	events := append([]models.PullRequestEvent{newEvent(prID, models.EventStatusChanged, pullRequest.Status, closedPullRequest)},
+		reviewerEvents(prID, models.EventReviewerRemoved, pullRequest.AssignedReviewers)...)
+	if err = s.recordEvents(ctx, tx, events...); err != nil {
+		return models.PullRequest{}, err
+	}
+
+	pullRequest, err = s.r.GetPullRequest(ctx, tx, prID)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.PullRequest{}, err
+	}
+	s.NotifyStaffing()
+
+	return pullRequest, nil
+}
+
+func (s *PullRequestService) openPullRequest(ctx context.Context, prID, from, action string) (models.PullRequest, error) {
+	tx, err := s.r.BeginTx(ctx)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	pullRequest, err := s.lockPullRequest(ctx, tx, prID)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+	if pullRequest.Status != from || !canTransition(from, openPullRequest) {
+		return models.PullRequest{}, models.ErrInvalidStatusTransition
+	}
+
+	if err = s.r.SetPullRequestStatus(ctx, tx, prID, openPullRequest, nil); err != nil {
+		return models.PullRequest{}, err
+	}
+	if err = s.recordEvents(ctx, tx, newEvent(prID, models.EventStatusChanged, from, openPullRequest)); err != nil {
+		return models.PullRequest{}, err
+	}
+	pullRequest.Status = openPullRequest
+
+	if from == draftPullRequest && pullRequest.RequiredReviewers == 0 {
+		if pullRequest.RequiredReviewers, err = s.teamReviewersCount(ctx, pullRequest.AuthorID); err != nil {
+			return models.PullRequest{}, err
+		}
+		if err = s.r.SetRequiredReviewers(ctx, tx, prID, pullRequest.RequiredReviewers); err != nil {
+			return models.PullRequest{}, err
+		}
+	}
+
+	reviewers, missing, err := s.staffPullRequest(ctx, tx, pullRequest, action)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+
+	pullRequest, err = s.r.GetPullRequest(ctx, tx, prID)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.PullRequest{}, err
+	}
+	if missing > 0 {
+		metrics.ObserveAssignment(action, missing, len(reviewers))
+	}
+	return pullRequest, nil
+}
+
+func (s *PullRequestService) teamReviewersCount(ctx context.Context, authorID string) (int, error) {
+	teamName, err := s.r.GetUsersTeam(ctx, authorID)
+	if err != nil {
+		return 0, err
+	}
+	settings, err := s.teamRepo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return 0, err
+	}
+	return settings.ReviewersCount, nil
+}
+
+func (s *PullRequestService) lockPullRequest(ctx context.Context, tx pgx.Tx, prID string) (models.PullRequest, error) {
+	if err := s.r.LockPullRequest(ctx, tx, prID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PullRequest{}, models.ErrPullRequestNotFound
+		}
+		return models.PullRequest{}, err
+	}
+	return s.r.GetPullRequest(ctx, tx, prID)
+}
+
+func (s *PullRequestService) staffPullRequest(ctx context.Context, tx pgx.Tx, pullRequest models.PullRequest, action string) ([]string, int, error) {
+	missing := pullRequest.RequiredReviewers - len(pullRequest.AssignedReviewers)
+	if missing <= 0 {
+		return nil, 0, nil
+	}
+
+	teamName, err := s.r.GetUsersTeam(ctx, pullRequest.AuthorID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	settings, err := s.teamRepo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	plan, err := s.planAssignment(ctx, tx, settings, assignmentRequest{
+		teamName:     teamName,
+		labels:       pullRequest.Labels,
+		changedFiles: pullRequest.ChangedFiles,
+		count:        missing,
+		now:          time.Now(),
+		find: func(teamName string) ([]models.ReviewerCandidate, error) {
+			return s.r.FindNewReviewer(ctx, tx, teamName, pullRequest.AuthorID, pullRequest.Id)
+		},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(plan.reviewers) == 0 {
+		return nil, missing, nil
+	}
+
+	if err = s.recordDecision(ctx, tx, pullRequest, action, "", settings, plan); err != nil {
+		return nil, 0, err
+	}
+	if err = s.r.AddReviewers(ctx, tx, pullRequest.Id, plan.reviewers); err != nil {
+		return nil, 0, err
+	}
+	if err = s.recordEvents(ctx, tx, reviewerEvents(pullRequest.Id, models.EventReviewerAssigned, plan.reviewers)...); err != nil {
+		return nil, 0, err
+	}
+	return plan.reviewers, missing, nil
+}
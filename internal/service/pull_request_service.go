@@ -3,7 +3,8 @@ package service
 import (
 	"context"
 	"errors"
-	"math/rand/v2"
+	"fmt"
+	"pull-request-reviewers-service/internal/metrics"
 	"pull-request-reviewers-service/internal/models"
 	"pull-request-reviewers-service/internal/repository"
 	"slices"
@@ -14,46 +15,47 @@ import (
 )
 
 const (
+	draftPullRequest  = "DRAFT"
 	openPullRequest   = "OPEN"
 	mergedPullRequest = "MERGED"
+	closedPullRequest = "CLOSED"
 )
 
 type PullRequestService struct {
-	r        *repository.PullRequestRepository
-	teamRepo *repository.TeamRepository
+	r          *repository.PullRequestRepository
+	teamRepo   *repository.TeamRepository
+	strategies map[string]ReviewerAssignmentStrategy
+	staffing   chan struct{}
 }
 
 func NewPullRequestService(r *repository.PullRequestRepository, teamRepo *repository.TeamRepository) *PullRequestService {
-	return &PullRequestService{r, teamRepo}
+	return &PullRequestService{r, teamRepo, newAssignmentStrategies(), make(chan struct{}, 1)}
 }
 
 func (s *PullRequestService) CreatePullRequest(ctx context.Context, prShort models.PullRequestShort) (models.PullRequest, error) {
 	pullRequest := models.PullRequest{
-		Id:        prShort.Id,
-		Name:      prShort.Name,
-		AuthorID:  prShort.AuthorID,
-		Status:    "OPEN",
-		CreatedAt: time.Now(),
+		Id:           prShort.Id,
+		Name:         prShort.Name,
+		AuthorID:     prShort.AuthorID,
+		Status:       openPullRequest,
+		CreatedAt:    time.Now(),
+		Labels:       prShort.Labels,
+		ChangedFiles: prShort.ChangedFiles,
 	}
-
-	teamName, err := s.r.GetUsersTeam(ctx, prShort.AuthorID)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return models.PullRequest{}, models.ErrAuthorNotFound
-		}
-		return models.PullRequest{}, err
+	if prShort.Draft {
+		pullRequest.Status = draftPullRequest
 	}
 
-	reviewers, err := s.r.FindPotentialReviewers(ctx, teamName, prShort.AuthorID)
+	teamName, settings, required, err := s.prepareAssignment(ctx, prShort)
 	if err != nil {
+		if errors.Is(err, models.ErrNotEnoughMembersInTeam) {
+			metrics.ObserveAssignment(models.AssignmentActionCreate, 1, 0)
+		}
 		return models.PullRequest{}, err
 	}
-
-	if len(reviewers) > 2 {
-		rand.Shuffle(len(reviewers), func(i, j int) {
-			reviewers[i], reviewers[j] = reviewers[j], reviewers[i]
-		})
-		reviewers = reviewers[:2]
+	pullRequest.RequiredReviewers = required
+	if prShort.Draft && prShort.ReviewersCount == nil {
+		pullRequest.RequiredReviewers = 0
 	}
 
 	tx, err := s.r.BeginTx(ctx)
@@ -71,7 +73,46 @@ func (s *PullRequestService) CreatePullRequest(ctx context.Context, prShort mode
 		return models.PullRequest{}, err
 	}
 
-	err = s.r.AddReviewers(ctx, tx, prShort.Id, reviewers)
+	if actorFrom(ctx) == nil {
+		ctx = WithActor(ctx, prShort.AuthorID)
+	}
+	err = s.recordEvents(ctx, tx, newEvent(pullRequest.Id, models.EventCreated, "", pullRequest.Status))
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+
+	if pullRequest.Status == draftPullRequest {
+		if err = tx.Commit(ctx); err != nil {
+			return models.PullRequest{}, err
+		}
+		return pullRequest, nil
+	}
+
+	plan, err := s.planAssignment(ctx, tx, settings, assignmentRequest{
+		teamName:     teamName,
+		labels:       prShort.Labels,
+		changedFiles: prShort.ChangedFiles,
+		count:        pullRequest.RequiredReviewers,
+		now:          pullRequest.CreatedAt,
+		find: func(teamName string) ([]models.ReviewerCandidate, error) {
+			return s.r.FindPotentialReviewers(ctx, teamName, prShort.AuthorID)
+		},
+	})
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+
+	err = s.recordDecision(ctx, tx, pullRequest, models.AssignmentActionCreate, "", settings, plan)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+
+	err = s.r.AddReviewers(ctx, tx, prShort.Id, plan.reviewers)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+
+	err = s.recordEvents(ctx, tx, reviewerEvents(prShort.Id, models.EventReviewerAssigned, plan.reviewers)...)
 	if err != nil {
 		return models.PullRequest{}, err
 	}
@@ -80,14 +121,88 @@ func (s *PullRequestService) CreatePullRequest(ctx context.Context, prShort mode
 	if err != nil {
 		return models.PullRequest{}, err
 	}
+	metrics.ObserveAssignment(models.AssignmentActionCreate, pullRequest.RequiredReviewers, len(plan.reviewers))
 
-	for _, reviewer := range reviewers {
+	for _, reviewer := range plan.reviewers {
 		pullRequest.AssignedReviewers = append(pullRequest.AssignedReviewers, reviewer)
+		pullRequest.Reviews = append(pullRequest.Reviews, models.Review{ReviewerID: reviewer, State: models.ReviewPending})
 	}
+	pullRequest.UncoveredLabels = plan.uncoveredLabels
+	pullRequest.CrossTeamReviewers = plan.crossTeamReviewers
+	pullRequest.PendingAssignment = len(plan.reviewers) < pullRequest.RequiredReviewers
 
 	return pullRequest, nil
 }
 
+func (s *PullRequestService) PreviewAssignment(ctx context.Context, prShort models.PullRequestShort) (models.AssignmentPreview, error) {
+	teamName, settings, required, err := s.prepareAssignment(ctx, prShort)
+	if err != nil {
+		return models.AssignmentPreview{}, err
+	}
+
+	plan, err := s.planAssignment(ctx, nil, settings, assignmentRequest{
+		teamName:     teamName,
+		labels:       prShort.Labels,
+		changedFiles: prShort.ChangedFiles,
+		count:        required,
+		now:          time.Now(),
+		readOnly:     true,
+		find: func(teamName string) ([]models.ReviewerCandidate, error) {
+			return s.r.FindPotentialReviewers(ctx, teamName, prShort.AuthorID)
+		},
+	})
+	if err != nil {
+		return models.AssignmentPreview{}, err
+	}
+
+	preview := models.AssignmentPreview{
+		TeamName:           teamName,
+		RequiredReviewers:  required,
+		Reviewers:          plan.reviewers,
+		CrossTeamReviewers: plan.crossTeamReviewers,
+		UncoveredLabels:    plan.uncoveredLabels,
+		Candidates:         make([]models.RankedCandidate, 0, len(plan.candidates)),
+	}
+	for i, candidate := range plan.candidates {
+		preview.Candidates = append(preview.Candidates, models.RankedCandidate{Rank: i + 1, ReviewerCandidate: candidate})
+	}
+	return preview, nil
+}
+
+func (s *PullRequestService) prepareAssignment(ctx context.Context, prShort models.PullRequestShort) (string, models.TeamSettings, int, error) {
+	teamName, err := s.r.GetUsersTeam(ctx, prShort.AuthorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", models.TeamSettings{}, 0, models.ErrAuthorNotFound
+		}
+		return "", models.TeamSettings{}, 0, err
+	}
+
+	settings, err := s.teamRepo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return "", models.TeamSettings{}, 0, err
+	}
+
+	reviewersCount := settings.ReviewersCount
+	if prShort.ReviewersCount != nil {
+		reviewersCount = *prShort.ReviewersCount
+		if reviewersCount <= 0 {
+			return "", models.TeamSettings{}, 0, models.ErrInvalidReviewersCount
+		}
+	}
+
+	if prShort.ReviewersCount != nil && !prShort.Draft {
+		available, err := s.r.CountAvailableReviewers(ctx, append([]string{teamName}, settings.FallbackTeams...), prShort.AuthorID)
+		if err != nil {
+			return "", models.TeamSettings{}, 0, err
+		}
+		if reviewersCount > available {
+			return "", models.TeamSettings{}, 0, models.ErrNotEnoughMembersInTeam
+		}
+	}
+	return teamName, settings, reviewersCount, nil
+}
+
 func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldReviewerID string) (models.PullRequest, string, error) {
 	tx, err := s.r.BeginTx(ctx)
 	if err != nil {
@@ -95,6 +210,12 @@ func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldRevi
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
+	newReviewerID, err := s.replaceReviewer(ctx, tx, prID, oldReviewerID, models.AssignmentActionReassign)
+	if err != nil {
+		observeFailedReplacement(models.AssignmentActionReassign, err)
+		return models.PullRequest{}, "", err
+	}
+
 	pullRequest, err := s.r.GetPullRequest(ctx, tx, prID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -103,57 +224,185 @@ func (s *PullRequestService) ReassignReviewer(ctx context.Context, prID, oldRevi
 		return models.PullRequest{}, "", err
 	}
 
+	if err = tx.Commit(ctx); err != nil {
+		return models.PullRequest{}, "", err
+	}
+	metrics.ObserveAssignment(models.AssignmentActionReassign, 1, 1)
+
+	return pullRequest, newReviewerID, nil
+}
+
+func (s *PullRequestService) DeclineReview(ctx context.Context, prID, reviewerID, reason string) (models.PullRequest, string, error) {
+	if !slices.Contains(models.DeclineReasons, reason) {
+		return models.PullRequest{}, "", models.ErrInvalidDeclineReason
+	}
+
+	tx, err := s.r.BeginTx(ctx)
+	if err != nil {
+		return models.PullRequest{}, "", err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if actorFrom(ctx) == nil {
+		ctx = WithActor(ctx, reviewerID)
+	}
+	newReviewerID, err := s.replaceReviewer(ctx, tx, prID, reviewerID, models.AssignmentActionDecline)
+	if err != nil {
+		observeFailedReplacement(models.AssignmentActionDecline, err)
+		return models.PullRequest{}, "", err
+	}
+
+	if err = s.r.SetDeclineReason(ctx, tx, prID, reviewerID, reason); err != nil {
+		return models.PullRequest{}, "", err
+	}
+
+	pullRequest, err := s.r.GetPullRequest(ctx, tx, prID)
+	if err != nil {
+		return models.PullRequest{}, "", err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.PullRequest{}, "", err
+	}
+	metrics.ObserveAssignment(models.AssignmentActionDecline, 1, 1)
+
+	return pullRequest, newReviewerID, nil
+}
+
+func observeFailedReplacement(action string, err error) {
+	if errors.Is(err, models.ErrNotEnoughMembersInTeam) {
+		metrics.ObserveAssignment(action, 1, 0)
+	}
+}
+
+func (s *PullRequestService) replaceReviewer(ctx context.Context, tx pgx.Tx, prID, oldReviewerID, action string) (string, error) {
+	pullRequest, err := s.r.GetPullRequest(ctx, tx, prID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", models.ErrPullRequestNotFound
+		}
+		return "", err
+	}
+
 	if pullRequest.Status == mergedPullRequest {
-		return models.PullRequest{}, "", models.ErrPullRequestAlreadyMerged
+		return "", models.ErrPullRequestAlreadyMerged
+	}
+	if pullRequest.Status != openPullRequest {
+		return "", models.ErrPullRequestNotOpen
 	}
 
 	if !slices.Contains(pullRequest.AssignedReviewers, oldReviewerID) {
-		return models.PullRequest{}, "", models.ErrUserNotReviewer
+		return "", models.ErrUserNotReviewer
 	}
 
 	oldReviewer, err := s.teamRepo.GetUser(ctx, oldReviewerID)
+	if err != nil {
+		return "", err
+	}
+
+	settings, err := s.teamRepo.GetTeamSettings(ctx, oldReviewer.TeamName)
+	if err != nil {
+		return "", err
+	}
+
+	plan, err := s.planAssignment(ctx, tx, settings, assignmentRequest{
+		teamName:     oldReviewer.TeamName,
+		labels:       pullRequest.Labels,
+		changedFiles: pullRequest.ChangedFiles,
+		count:        1,
+		now:          time.Now(),
+		find: func(teamName string) ([]models.ReviewerCandidate, error) {
+			return s.r.FindNewReviewer(ctx, tx, teamName, pullRequest.AuthorID, prID)
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(plan.reviewers) == 0 {
+		return "", models.ErrNotEnoughMembersInTeam
+	}
+	newReviewerID := plan.reviewers[0]
+
+	err = s.recordDecision(ctx, tx, pullRequest, action, oldReviewerID, settings, plan)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.r.UpdateReviewer(ctx, tx, prID, newReviewerID, oldReviewerID, action)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return models.PullRequest{}, "", models.ErrUserNotFound
+			return "", models.ErrUserNotReviewer
 		}
-		return models.PullRequest{}, "", err
+		return "", err
 	}
 
-	reviewers, err := s.r.FindNewReviewer(ctx, tx, oldReviewer.TeamName, pullRequest.AuthorID, prID)
+	err = s.recordEvents(ctx, tx, newEvent(prID, models.EventReviewerReplaced, oldReviewerID, newReviewerID))
 	if err != nil {
-		return models.PullRequest{}, "", err
+		return "", err
 	}
+	return newReviewerID, nil
+}
 
-	if len(reviewers) == 0 {
-		return models.PullRequest{}, "", models.ErrNotEnoughMembersInTeam
+func (s *PullRequestService) SubmitReview(ctx context.Context, prID, reviewerID, state string) (models.PullRequest, error) {
+	if !slices.Contains([]string{models.ReviewApproved, models.ReviewChangesRequested, models.ReviewCommented}, state) {
+		return models.PullRequest{}, models.ErrInvalidReviewState
 	}
 
-	newReviewerID := reviewers[rand.IntN(len(reviewers))]
+	tx, err := s.r.BeginTx(ctx)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
 
-	err = s.r.UpdateReviewer(ctx, tx, prID, newReviewerID, oldReviewerID)
+	pullRequest, err := s.r.GetPullRequest(ctx, tx, prID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return models.PullRequest{}, "", models.ErrUserNotReviewer
+			return models.PullRequest{}, models.ErrPullRequestNotFound
 		}
-		return models.PullRequest{}, "", err
+		return models.PullRequest{}, err
 	}
 
-	pullRequest, err = s.r.GetPullRequest(ctx, tx, prID)
+	if pullRequest.Status == mergedPullRequest {
+		return models.PullRequest{}, models.ErrPullRequestAlreadyMerged
+	}
+	if pullRequest.Status != openPullRequest {
+		return models.PullRequest{}, models.ErrPullRequestNotOpen
+	}
+
+	err = s.r.SetReviewState(ctx, tx, prID, reviewerID, state, time.Now())
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return models.PullRequest{}, "", models.ErrPullRequestNotFound
+			return models.PullRequest{}, models.ErrUserNotReviewer
 		}
-		return models.PullRequest{}, "", err
+		return models.PullRequest{}, err
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return models.PullRequest{}, "", err
+	previousState := models.ReviewPending
+	for _, review := range pullRequest.Reviews {
+		if review.ReviewerID == reviewerID {
+			previousState = review.State
+		}
+	}
+	if actorFrom(ctx) == nil {
+		ctx = WithActor(ctx, reviewerID)
+	}
+	err = s.recordEvents(ctx, tx, newEvent(prID, models.EventReviewSubmitted, previousState, state))
+	if err != nil {
+		return models.PullRequest{}, err
 	}
 
-	return pullRequest, newReviewerID, nil
+	pullRequest, err = s.r.GetPullRequest(ctx, tx, prID)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.PullRequest{}, err
+	}
+	return pullRequest, nil
 }
 
-func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string) (models.PullRequest, error) {
+func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string, force bool) (models.PullRequest, error) {
 	tx, err := s.r.BeginTx(ctx)
 	if err != nil {
 		return models.PullRequest{}, err
@@ -171,6 +420,14 @@ func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string)
 	if pullRequest.Status == mergedPullRequest {
 		return pullRequest, nil
 	}
+	if !canTransition(pullRequest.Status, mergedPullRequest) {
+		return models.PullRequest{}, models.ErrInvalidStatusTransition
+	}
+
+	bypassed, err := s.checkApprovals(ctx, pullRequest, force)
+	if err != nil {
+		return models.PullRequest{}, err
+	}
 
 	if pullRequest.Status == openPullRequest {
 		mergedAt := time.Now()
@@ -181,6 +438,14 @@ func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string)
 			}
 			return models.PullRequest{}, err
 		}
+
+		events := []models.PullRequestEvent{newEvent(prID, models.EventStatusChanged, pullRequest.Status, mergedPullRequest)}
+		if bypassed != "" {
+			events = append(events, newEvent(prID, models.EventForceMerged, "", bypassed))
+		}
+		if err = s.recordEvents(ctx, tx, events...); err != nil {
+			return models.PullRequest{}, err
+		}
 	}
 
 	updatedPr, err := s.r.GetPullRequest(ctx, tx, prID)
@@ -194,10 +459,59 @@ func (s *PullRequestService) MergePullRequest(ctx context.Context, prID string)
 	if err = tx.Commit(ctx); err != nil {
 		return models.PullRequest{}, err
 	}
+	s.NotifyStaffing()
 
 	return updatedPr, nil
 }
 
-func (s *PullRequestService) GetAssignStat(ctx context.Context) ([]models.ReviewerStat, error) {
-	return s.r.GetAssignStat(ctx)
+func (s *PullRequestService) checkApprovals(ctx context.Context, pullRequest models.PullRequest, force bool) (string, error) {
+	teamName, err := s.r.GetUsersTeam(ctx, pullRequest.AuthorID)
+	if err != nil {
+		return "", err
+	}
+
+	settings, err := s.teamRepo.GetTeamSettings(ctx, teamName)
+	if err != nil {
+		return "", err
+	}
+
+	return approvalBypass(settings, pullRequest.Reviews, force, actorFrom(ctx))
+}
+
+func approvalBypass(settings models.TeamSettings, reviews []models.Review, force bool, actor *string) (string, error) {
+	approvals := 0
+	for _, review := range reviews {
+		if review.State == models.ReviewApproved {
+			approvals++
+		}
+	}
+	if approvals >= settings.RequiredApprovals {
+		return "", nil
+	}
+	if !force {
+		return "", fmt.Errorf("%w: %d of %d", models.ErrNotEnoughApprovals, approvals, settings.RequiredApprovals)
+	}
+
+	if actor == nil {
+		return "", models.ErrActorRequired
+	}
+	if settings.TeamLead == nil || *actor != *settings.TeamLead {
+		return "", models.ErrForceMergeForbidden
+	}
+	return fmt.Sprintf("%d of %d approvals", approvals, settings.RequiredApprovals), nil
+}
+
+func (s *PullRequestService) strategy(settings models.TeamSettings) ReviewerAssignmentStrategy {
+	strategy, ok := s.strategies[settings.AssignmentStrategy]
+	if !ok {
+		return s.strategies[models.StrategyLeastLoaded]
+	}
+	return strategy
+}
+
+func (s *PullRequestService) GetAssignStat(ctx context.Context, filter models.StatsFilter) ([]models.ReviewerStat, error) {
+	if err := validateStatsFilter(filter); err != nil {
+		return nil, err
+	}
+	return s.r.GetAssignStat(ctx, filter)
 }
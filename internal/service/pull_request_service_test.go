@@ -0,0 +1,44 @@
+package service
+
+import (
+	"errors"
+	"pull-request-reviewers-service/internal/models"
+	"testing"
+)
+
+func TestApprovalBypass(t *testing.T) {
+	lead := "lead"
+	reviews := []models.Review{
+		{ReviewerID: "u1", State: models.ReviewApproved},
+		{ReviewerID: "u2", State: models.ReviewPending},
+	}
+	tests := []struct {
+		name     string
+		required int
+		teamLead *string
+		force    bool
+		actor    *string
+		wantErr  error
+		bypassed bool
+	}{
+		{name: "enough approvals", required: 1, teamLead: &lead, actor: ptr("u3")},
+		{name: "not enough approvals", required: 2, teamLead: &lead, actor: &lead, wantErr: models.ErrNotEnoughApprovals},
+		{name: "team lead forces", required: 2, teamLead: &lead, force: true, actor: &lead, bypassed: true},
+		{name: "non-lead forces", required: 2, teamLead: &lead, force: true, actor: ptr("u1"), wantErr: models.ErrForceMergeForbidden},
+		{name: "author forces", required: 2, teamLead: &lead, force: true, actor: ptr("author"), wantErr: models.ErrForceMergeForbidden},
+		{name: "no actor header", required: 2, teamLead: &lead, force: true, wantErr: models.ErrActorRequired},
+		{name: "team without lead", required: 2, force: true, actor: &lead, wantErr: models.ErrForceMergeForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := models.TeamSettings{RequiredApprovals: tt.required, TeamLead: tt.teamLead}
+			bypass, err := approvalBypass(settings, reviews, tt.force, tt.actor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("approvalBypass() error = %v, want %v", err, tt.wantErr)
+			}
+			if (bypass != "") != tt.bypassed {
+				t.Errorf("approvalBypass() bypass = %q, want bypassed %v", bypass, tt.bypassed)
+			}
+		})
+	}
+}
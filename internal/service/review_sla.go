@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"pull-request-reviewers-service/internal/metrics"
+	"pull-request-reviewers-service/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *PullRequestService) RunReviewSLA(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.enforceReviewSLA(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *PullRequestService) GetOverdueReviews(ctx context.Context, teamName string) ([]models.OverdueReview, error) {
+	pending, err := s.r.FindPendingReviews(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	overdue := make([]models.OverdueReview, 0)
+	for _, review := range pending {
+		deadline := reviewDeadline(review)
+		if deadline.After(now) {
+			continue
+		}
+		overdue = append(overdue, models.OverdueReview{
+			PullRequestID: review.PullRequestID,
+			ReviewerID:    review.ReviewerID,
+			TeamName:      review.TeamName,
+			AssignedAt:    review.AssignedAt,
+			Deadline:      deadline,
+			Escalated:     review.Escalated,
+		})
+	}
+	return overdue, nil
+}
+
+func reviewDeadline(review models.PendingReview) time.Time {
+	sla := time.Duration(review.SLAHours) * time.Hour
+	window, ok := candidateWindow(models.ReviewerCandidate{
+		TimeZone:  review.TimeZone,
+		WorkStart: review.WorkStart,
+		WorkEnd:   review.WorkEnd,
+	})
+	if !ok {
+		return review.AssignedAt.Add(sla)
+	}
+	return window.addWorkingTime(review.AssignedAt, sla)
+}
+
+func (s *PullRequestService) enforceReviewSLA(ctx context.Context) {
+	pending, err := s.r.FindPendingReviews(ctx, "")
+	if err != nil {
+		log.Printf("sla worker: find pending reviews: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, review := range pending {
+		if review.Escalated || reviewDeadline(review).After(now) {
+			continue
+		}
+		if err = s.handleOverdueReview(ctx, review); err != nil {
+			log.Printf("sla worker: %s on %s: %v", review.ReviewerID, review.PullRequestID, err)
+		}
+	}
+}
+
+func (s *PullRequestService) handleOverdueReview(ctx context.Context, review models.PendingReview) error {
+	tx, err := s.r.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	state, assignedAt, err := s.r.LockReviewer(ctx, tx, review.PullRequestID, review.ReviewerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if state != models.ReviewPending || !assignedAt.Equal(review.AssignedAt) {
+		return nil
+	}
+
+	escalation := models.ReviewEscalation{
+		PullRequestID: review.PullRequestID,
+		ReviewerID:    review.ReviewerID,
+		AssignedAt:    review.AssignedAt,
+		Action:        review.SLAAction,
+		EscalatedAt:   time.Now(),
+	}
+
+	if review.SLAAction == models.SLAActionReassign {
+		newReviewerID, err := s.replaceReviewer(ctx, tx, review.PullRequestID, review.ReviewerID, models.AssignmentActionSLA)
+		switch {
+		case errors.Is(err, models.ErrNotEnoughMembersInTeam):
+			escalation.Action = models.SLAActionEscalate
+		case err != nil:
+			return err
+		default:
+			escalation.EscalatedTo = &newReviewerID
+		}
+	}
+	if escalation.Action == models.SLAActionEscalate {
+		escalation.EscalatedTo = review.TeamLead
+		if review.TeamLead == nil {
+			log.Printf("sla worker: review of %s by %s is overdue, but team %s has no team lead",
+				review.PullRequestID, review.ReviewerID, review.TeamName)
+		} else {
+			log.Printf("sla worker: review of %s by %s is overdue, escalated to team lead %s",
+				review.PullRequestID, review.ReviewerID, *review.TeamLead)
+		}
+	}
+
+	if err = s.r.AddReviewEscalation(ctx, tx, escalation); err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+	switch {
+	case escalation.Action == models.SLAActionReassign:
+		metrics.ObserveAssignment(models.AssignmentActionSLA, 1, 1)
+	case review.SLAAction == models.SLAActionReassign:
+		metrics.ObserveAssignment(models.AssignmentActionSLA, 1, 0)
+	}
+	return nil
+}
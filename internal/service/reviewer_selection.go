@@ -0,0 +1,304 @@
+package service
+
+import (
+	"context"
+	"pull-request-reviewers-service/internal/models"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type assignmentRequest struct {
+	teamName     string
+	labels       []string
+	changedFiles []string
+	count        int
+	now          time.Time
+	readOnly     bool
+	reserved     bool
+	cursors      *roundRobinCursors
+	codeOwners   map[string]codeOwnersMatcher
+	find         func(teamName string) ([]models.ReviewerCandidate, error)
+}
+
+type assignmentPlan struct {
+	reviewers          []string
+	rules              map[string]string
+	teams              []string
+	unusedTeams        []string
+	candidates         []models.ReviewerCandidate
+	crossTeamReviewers []string
+	uncoveredLabels    []string
+}
+
+func (s *PullRequestService) planAssignment(ctx context.Context, tx pgx.Tx, settings models.TeamSettings, req assignmentRequest) (assignmentPlan, error) {
+	candidates, err := req.find(req.teamName)
+	if err != nil {
+		return assignmentPlan{}, err
+	}
+
+	fallback, err := s.fallbackTiers(ctx, settings, len(candidates), req.count, req.find)
+	if err != nil {
+		return assignmentPlan{}, err
+	}
+
+	tiers, err := s.codeOwnerTiers(ctx, req.teamName, req.changedFiles, candidates, req.codeOwners)
+	if err != nil {
+		return assignmentPlan{}, err
+	}
+	tiers = append(tiers, fallback...)
+
+	cursors := req.cursors
+	if cursors == nil {
+		cursors = newRoundRobinCursors(s.teamRepo, tx, settings.AssignmentStrategy, req.readOnly)
+	}
+	reviewers, rules, err := s.pickReviewers(ctx, tx, settings, cursors, tiers, req)
+	if err != nil {
+		return assignmentPlan{}, err
+	}
+	if req.cursors == nil {
+		if err = cursors.save(ctx); err != nil {
+			return assignmentPlan{}, err
+		}
+	}
+	tiers = workingHoursTiers(labelTiers(tiers, req.labels), req.now)
+
+	ranked, err := rankCandidates(ctx, s.strategy(settings), cursors, tiers)
+	if err != nil {
+		return assignmentPlan{}, err
+	}
+
+	plan := assignmentPlan{
+		reviewers:       reviewers,
+		rules:           rules,
+		teams:           []string{req.teamName},
+		candidates:      ranked,
+		uncoveredLabels: uncoveredLabels(req.labels, tierCandidates(tiers), reviewers),
+	}
+	for _, tier := range fallback {
+		plan.teams = append(plan.teams, tier.teamName)
+	}
+	for _, fallbackTeam := range settings.FallbackTeams {
+		if !slices.Contains(plan.teams, fallbackTeam) {
+			plan.unusedTeams = append(plan.unusedTeams, fallbackTeam)
+		}
+	}
+	for _, candidate := range tierCandidates(fallback) {
+		if slices.Contains(reviewers, candidate.UserID) {
+			plan.crossTeamReviewers = append(plan.crossTeamReviewers, candidate.UserID)
+		}
+	}
+	return plan, nil
+}
+
+type reviewerTier struct {
+	teamName   string
+	candidates []models.ReviewerCandidate
+	reasons    []string
+}
+
+func (t reviewerTier) narrow(candidates []models.ReviewerCandidate, reason string) reviewerTier {
+	reasons := slices.Clone(t.reasons)
+	if reason != "" {
+		reasons = append(reasons, reason)
+	}
+	return reviewerTier{teamName: t.teamName, candidates: candidates, reasons: reasons}
+}
+
+func (t reviewerTier) rule(strategy string) string {
+	return strings.Join(append([]string{strategy}, t.reasons...), ", ")
+}
+
+func (s *PullRequestService) pickReviewers(ctx context.Context, tx pgx.Tx, settings models.TeamSettings, cursors *roundRobinCursors,
+	base []reviewerTier, req assignmentRequest) ([]string, map[string]string, error) {
+	strategy := s.strategy(settings)
+	var reviewers, skipped []string
+	if !req.readOnly && !req.reserved && req.count > 0 {
+		var err error
+		if skipped, err = s.reserveCandidates(ctx, tx, tierCandidates(base)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var picked []models.ReviewerCandidate
+	rules := make(map[string]string)
+	for len(reviewers) < req.count {
+		tiers := workingHoursTiers(labelTiers(base, uncoveredLabels(req.labels, picked, reviewers)), req.now)
+		tier, remaining, ok := nextTier(tiers, append(slices.Clone(reviewers), skipped...))
+		if !ok {
+			break
+		}
+
+		lastUserID, err := cursors.get(ctx, tier.teamName)
+		if err != nil {
+			return nil, nil, err
+		}
+		candidate := strategy.Rank(remaining, lastUserID)[0]
+		reviewers = append(reviewers, candidate.UserID)
+		picked = append(picked, candidate)
+		rules[candidate.UserID] = tier.rule(settings.AssignmentStrategy)
+		cursors.advance(tier.teamName, candidate.UserID)
+	}
+	return reviewers, rules, nil
+}
+
+func (s *PullRequestService) reserveCandidates(ctx context.Context, tx pgx.Tx, candidates []models.ReviewerCandidate) ([]string, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	userIDs := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		userIDs = append(userIDs, candidate.UserID)
+	}
+
+	fitting, err := s.r.ReserveReviewers(ctx, tx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(userIDs, func(userID string) bool {
+		return slices.Contains(fitting, userID)
+	}), nil
+}
+
+func nextTier(tiers []reviewerTier, taken []string) (reviewerTier, []models.ReviewerCandidate, bool) {
+	for _, tier := range tiers {
+		remaining := slices.DeleteFunc(slices.Clone(tier.candidates), func(candidate models.ReviewerCandidate) bool {
+			return slices.Contains(taken, candidate.UserID)
+		})
+		if len(remaining) > 0 {
+			return tier, remaining, true
+		}
+	}
+	return reviewerTier{}, nil, false
+}
+
+func (s *PullRequestService) codeOwnerTiers(ctx context.Context, teamName string, changedFiles []string, candidates []models.ReviewerCandidate,
+	cache map[string]codeOwnersMatcher) ([]reviewerTier, error) {
+	tier := reviewerTier{teamName: teamName, candidates: candidates}
+	if len(changedFiles) == 0 {
+		return []reviewerTier{tier}, nil
+	}
+
+	matcher, ok := cache[teamName]
+	if !ok {
+		content, err := s.teamRepo.GetCodeOwners(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		if matcher, err = parseCodeOwners(content); err != nil {
+			return nil, err
+		}
+		if cache != nil {
+			cache[teamName] = matcher
+		}
+	}
+
+	owners := matcher.OwnersOf(changedFiles)
+	owned, rest := partitionCandidates(candidates, func(candidate models.ReviewerCandidate) bool {
+		return owners[candidate.UserID]
+	})
+	return []reviewerTier{tier.narrow(owned, "codeowner"), tier.narrow(rest, "")}, nil
+}
+
+func (s *PullRequestService) fallbackTiers(ctx context.Context, settings models.TeamSettings, have, need int,
+	find func(teamName string) ([]models.ReviewerCandidate, error)) ([]reviewerTier, error) {
+	var tiers []reviewerTier
+	for _, fallbackTeam := range settings.FallbackTeams {
+		if have >= need {
+			break
+		}
+		candidates, err := find(fallbackTeam)
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, reviewerTier{teamName: fallbackTeam, candidates: candidates, reasons: []string{"fallback_team"}})
+		have += len(candidates)
+	}
+	return tiers, nil
+}
+
+func partitionCandidates(candidates []models.ReviewerCandidate, preferred func(models.ReviewerCandidate) bool) ([]models.ReviewerCandidate, []models.ReviewerCandidate) {
+	var matched, rest []models.ReviewerCandidate
+	for _, candidate := range candidates {
+		if preferred(candidate) {
+			matched = append(matched, candidate)
+		} else {
+			rest = append(rest, candidate)
+		}
+	}
+	return matched, rest
+}
+
+func labelTiers(tiers []reviewerTier, labels []string) []reviewerTier {
+	refined := make([]reviewerTier, 0, len(tiers)*2)
+	for _, tier := range tiers {
+		byGain := make(map[int][]models.ReviewerCandidate)
+		for _, candidate := range tier.candidates {
+			gain := labelGain(labels, candidate)
+			byGain[gain] = append(byGain[gain], candidate)
+		}
+		for gain := len(labels); gain > 0; gain-- {
+			if len(byGain[gain]) > 0 {
+				refined = append(refined, tier.narrow(byGain[gain], "label_match"))
+			}
+		}
+		refined = append(refined, tier.narrow(byGain[0], ""))
+	}
+	return refined
+}
+
+func labelGain(labels []string, candidate models.ReviewerCandidate) int {
+	var gain int
+	for _, label := range labels {
+		if slices.Contains(candidate.Skills, label) {
+			gain++
+		}
+	}
+	return gain
+}
+
+func tierCandidates(tiers []reviewerTier) []models.ReviewerCandidate {
+	var candidates []models.ReviewerCandidate
+	for _, tier := range tiers {
+		for _, candidate := range tier.candidates {
+			if !slices.ContainsFunc(candidates, func(c models.ReviewerCandidate) bool { return c.UserID == candidate.UserID }) {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	return candidates
+}
+
+func rankCandidates(ctx context.Context, strategy ReviewerAssignmentStrategy, cursors *roundRobinCursors, tiers []reviewerTier) ([]models.ReviewerCandidate, error) {
+	ranked := make([]reviewerTier, 0, len(tiers))
+	for _, tier := range tiers {
+		lastUserID, err := cursors.start(ctx, tier.teamName)
+		if err != nil {
+			return nil, err
+		}
+		ranked = append(ranked, tier.narrow(strategy.Rank(tier.candidates, lastUserID), ""))
+	}
+	return tierCandidates(ranked), nil
+}
+
+func uncoveredLabels(labels []string, candidates []models.ReviewerCandidate, reviewers []string) []string {
+	covered := make(map[string]bool)
+	for _, candidate := range candidates {
+		if !slices.Contains(reviewers, candidate.UserID) {
+			continue
+		}
+		for _, skill := range candidate.Skills {
+			covered[skill] = true
+		}
+	}
+
+	var uncovered []string
+	for _, label := range labels {
+		if !covered[label] {
+			uncovered = append(uncovered, label)
+		}
+	}
+	return uncovered
+}
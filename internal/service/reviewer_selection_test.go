@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"pull-request-reviewers-service/internal/models"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPickReviewersLabelCoverage(t *testing.T) {
+	tests := []struct {
+		name          string
+		skills        map[string][]string
+		labels        []string
+		lastUserID    string
+		count         int
+		want          []string
+		wantUncovered []string
+	}{
+		{
+			name:   "widest coverage first, then the rest",
+			skills: map[string][]string{"u1": {"a"}, "u2": {"a", "b"}, "u3": {"c"}},
+			labels: []string{"a", "b", "c"},
+			count:  2,
+			want:   []string{"u2", "u3"},
+		},
+		{
+			name:   "prefers uncovered labels over already covered ones",
+			skills: map[string][]string{"u1": {"a"}, "u2": {"a"}, "u3": {"b"}},
+			labels: []string{"a", "b"},
+			count:  2,
+			want:   []string{"u1", "u3"},
+		},
+		{
+			name:       "ties fall back to strategy order",
+			skills:     map[string][]string{"u1": {"a"}, "u2": {"a"}, "u3": {"a"}},
+			labels:     []string{"a"},
+			lastUserID: "u1",
+			count:      1,
+			want:       []string{"u2"},
+		},
+		{
+			name:       "strategy order once every label is covered",
+			skills:     map[string][]string{"u1": {"a"}, "u2": {}, "u3": {}, "u4": {}},
+			labels:     []string{"a"},
+			lastUserID: "u2",
+			count:      3,
+			want:       []string{"u1", "u2", "u3"},
+		},
+		{
+			name:          "reports labels nobody covers",
+			skills:        map[string][]string{"u1": {"a"}, "u2": {}},
+			labels:        []string{"a", "z"},
+			count:         2,
+			want:          []string{"u1", "u2"},
+			wantUncovered: []string{"z"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var candidates []models.ReviewerCandidate
+			for userID, skills := range tt.skills {
+				candidates = append(candidates, models.ReviewerCandidate{UserID: userID, TeamName: "backend", Skills: skills})
+			}
+			service := NewPullRequestService(nil, nil)
+			settings := models.TeamSettings{AssignmentStrategy: models.StrategyRoundRobin}
+			cursors := seededCursors(models.StrategyRoundRobin, map[string]string{"backend": tt.lastUserID})
+			tiers := []reviewerTier{{teamName: "backend", candidates: candidates}}
+
+			got, _, err := service.pickReviewers(context.Background(), nil, settings, cursors, tiers,
+				assignmentRequest{labels: tt.labels, count: tt.count, readOnly: true, now: time.Now()})
+			if err != nil {
+				t.Fatalf("pickReviewers: %v", err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("reviewers = %v, want %v", got, tt.want)
+			}
+			if uncovered := uncoveredLabels(tt.labels, candidates, got); !slices.Equal(uncovered, tt.wantUncovered) {
+				t.Errorf("uncovered labels = %v, want %v", uncovered, tt.wantUncovered)
+			}
+		})
+	}
+}
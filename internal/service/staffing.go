@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"log"
+	"pull-request-reviewers-service/internal/metrics"
+	"pull-request-reviewers-service/internal/models"
+	"time"
+)
+
+func (s *PullRequestService) NotifyStaffing() {
+	select {
+	case s.staffing <- struct{}{}:
+	default:
+	}
+}
+
+func (s *PullRequestService) RunStaffing(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.fillUnderstaffed(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.staffing:
+		}
+	}
+}
+
+func (s *PullRequestService) GetUnderstaffedPullRequests(ctx context.Context) ([]models.PullRequest, error) {
+	prIDs, err := s.r.FindUnderstaffedPRs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.r.BeginTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	pullRequests := make([]models.PullRequest, 0, len(prIDs))
+	for _, prID := range prIDs {
+		pullRequest, err := s.r.GetPullRequest(ctx, tx, prID)
+		if err != nil {
+			return nil, err
+		}
+		pullRequests = append(pullRequests, pullRequest)
+	}
+	return pullRequests, nil
+}
+
+func (s *PullRequestService) fillUnderstaffed(ctx context.Context) {
+	prIDs, err := s.r.FindUnderstaffedPRs(ctx)
+	if err != nil {
+		log.Printf("staffing worker: find understaffed: %v", err)
+		return
+	}
+
+	for _, prID := range prIDs {
+		if err = s.fillMissingReviewers(ctx, prID); err != nil {
+			log.Printf("staffing worker: fill %s: %v", prID, err)
+		}
+	}
+}
+
+func (s *PullRequestService) fillMissingReviewers(ctx context.Context, prID string) error {
+	tx, err := s.r.BeginTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	pullRequest, err := s.lockPullRequest(ctx, tx, prID)
+	if err != nil {
+		return err
+	}
+	if !pullRequest.PendingAssignment {
+		return nil
+	}
+
+	reviewers, missing, err := s.staffPullRequest(ctx, tx, pullRequest, models.AssignmentActionFill)
+	if err != nil {
+		return err
+	}
+	if len(reviewers) == 0 {
+		return nil
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+	metrics.ObserveAssignment(models.AssignmentActionFill, missing, len(reviewers))
+	return nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"pull-request-reviewers-service/internal/models"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+)
+
+var pullRequestStatuses = []string{draftPullRequest, openPullRequest, mergedPullRequest, closedPullRequest}
+
+func validateStatsFilter(filter models.StatsFilter) error {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return fmt.Errorf("%w: from must be before to", models.ErrInvalidStatsFilter)
+	}
+	if filter.Status != "" && !slices.Contains(pullRequestStatuses, filter.Status) {
+		return fmt.Errorf("%w: unknown status %q", models.ErrInvalidStatsFilter, filter.Status)
+	}
+	if filter.Bucket != "" && !slices.Contains(models.StatBuckets, filter.Bucket) {
+		return fmt.Errorf("%w: bucket must be day, week or month", models.ErrInvalidStatsFilter)
+	}
+	return nil
+}
+
+func validatePeriodFilter(filter models.StatsFilter) error {
+	if filter.Status != "" || filter.Bucket != "" {
+		return fmt.Errorf("%w: status and bucket are not supported here", models.ErrInvalidStatsFilter)
+	}
+	return validateStatsFilter(filter)
+}
+
+func (s *PullRequestService) GetReviewMetrics(ctx context.Context, filter models.StatsFilter) (models.MetricsResponse, error) {
+	if err := validatePeriodFilter(filter); err != nil {
+		return models.MetricsResponse{}, err
+	}
+
+	teams, err := s.r.GetTeamMetrics(ctx, filter)
+	if err != nil {
+		return models.MetricsResponse{}, err
+	}
+	reviewers, err := s.r.GetReviewerMetrics(ctx, filter)
+	if err != nil {
+		return models.MetricsResponse{}, err
+	}
+
+	metrics := models.MetricsResponse{
+		Teams:     make([]models.TeamMetrics, 0, len(teams)),
+		Reviewers: make([]models.ReviewerMetrics, 0, len(reviewers)),
+	}
+	for _, teamName := range slices.Sorted(maps.Keys(teams)) {
+		metrics.Teams = append(metrics.Teams, models.TeamMetrics{TeamName: teamName, ReviewMetrics: teams[teamName]})
+	}
+	for _, reviewerID := range slices.Sorted(maps.Keys(reviewers)) {
+		metrics.Reviewers = append(metrics.Reviewers, models.ReviewerMetrics{ReviewerID: reviewerID, ReviewMetrics: reviewers[reviewerID]})
+	}
+	return metrics, nil
+}
+
+func (s *PullRequestService) CountPullRequestsByStatus(ctx context.Context) (map[string]int, error) {
+	return s.r.CountPullRequestsByStatus(ctx)
+}
+
+func (s *PullRequestService) CountActiveMembersByTeam(ctx context.Context) (map[string]int, error) {
+	return s.r.CountActiveMembersByTeam(ctx)
+}
+
+func (s *PullRequestService) GetPairingMatrix(ctx context.Context, filter models.StatsFilter) (models.PairingMatrix, error) {
+	if filter.TeamName == "" {
+		return models.PairingMatrix{}, models.ErrTeamNameRequired
+	}
+	if err := validatePeriodFilter(filter); err != nil {
+		return models.PairingMatrix{}, err
+	}
+
+	team, err := s.teamRepo.GetTeam(ctx, filter.TeamName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.PairingMatrix{}, models.ErrTeamNotFound
+		}
+		return models.PairingMatrix{}, err
+	}
+
+	pairings, err := s.r.GetReviewPairings(ctx, filter)
+	if err != nil {
+		return models.PairingMatrix{}, err
+	}
+
+	matrix := models.PairingMatrix{TeamName: team.Name, Members: make([]string, 0, len(team.Members))}
+	for _, member := range team.Members {
+		matrix.Members = append(matrix.Members, member.Id)
+	}
+	slices.Sort(matrix.Members)
+
+	matrix.Matrix = make([][]int, len(matrix.Members))
+	for i := range matrix.Matrix {
+		matrix.Matrix[i] = make([]int, len(matrix.Members))
+	}
+	for _, pairing := range pairings {
+		author, authorOK := slices.BinarySearch(matrix.Members, pairing.AuthorID)
+		reviewer, reviewerOK := slices.BinarySearch(matrix.Members, pairing.ReviewerID)
+		if authorOK && reviewerOK {
+			matrix.Matrix[author][reviewer] = pairing.Reviews
+		}
+	}
+	return matrix, nil
+}
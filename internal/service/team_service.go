@@ -5,17 +5,19 @@ import (
 	"errors"
 	"pull-request-reviewers-service/internal/models"
 	"pull-request-reviewers-service/internal/repository"
+	"slices"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
 type TeamService struct {
-	r *repository.TeamRepository
+	r         *repository.TeamRepository
+	prService *PullRequestService
 }
 
-func NewTeamService(r *repository.TeamRepository) *TeamService {
-	return &TeamService{r}
+func NewTeamService(r *repository.TeamRepository, prService *PullRequestService) *TeamService {
+	return &TeamService{r, prService}
 }
 
 func (s *TeamService) CreateTeam(ctx context.Context, team models.Team) (models.Team, error) {
@@ -34,6 +36,13 @@ func (s *TeamService) CreateTeam(ctx context.Context, team models.Team) (models.
 		return models.Team{}, err
 	}
 	for _, user := range team.Members {
+		user, err = withWorkingHours(user)
+		if err != nil {
+			return models.Team{}, err
+		}
+		if user.MaxOpenReviews != nil && *user.MaxOpenReviews <= 0 {
+			return models.Team{}, models.ErrInvalidCapacity
+		}
 		err = s.r.CreateUpdateUser(ctx, tx, user, team.Name)
 		if err != nil {
 			return models.Team{}, err
@@ -43,6 +52,7 @@ func (s *TeamService) CreateTeam(ctx context.Context, team models.Team) (models.
 	if err = tx.Commit(ctx); err != nil {
 		return models.Team{}, err
 	}
+	s.prService.NotifyStaffing()
 
 	createdTeam, err := s.r.GetTeam(ctx, team.Name)
 	if err != nil {
@@ -60,12 +70,87 @@ func (s *TeamService) GetTeam(ctx context.Context, teamName string) (models.Team
 	return team, nil
 }
 
-func (s *TeamService) SetIsActive(ctx context.Context, userID string, isActive bool) (models.User, error) {
-	user, err := s.r.SetIsActiveUser(ctx, userID, isActive)
-	if errors.Is(err, pgx.ErrNoRows) {
-		return models.User{}, models.ErrUserNotFound
+func (s *TeamService) SetIsActive(ctx context.Context, userID string, isActive bool, reassign *bool) (models.User, []models.PullRequestReassignment, error) {
+	tx, err := s.r.DB.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return models.User{}, nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	user, err := s.r.SetIsActiveUser(ctx, tx, userID, isActive)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, nil, models.ErrUserNotFound
+		}
+		return models.User{}, nil, err
+	}
+
+	var reassigned []models.PullRequestReassignment
+	if !isActive {
+		if reassign == nil {
+			settings, err := s.r.GetTeamSettings(ctx, user.TeamName)
+			if err != nil {
+				return models.User{}, nil, err
+			}
+			reassign = &settings.ReassignOnDeactivate
+		}
+		if *reassign {
+			reassigned, err = s.prService.reassignOpenReviews(ctx, tx, userID)
+			if err != nil {
+				return models.User{}, nil, err
+			}
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.User{}, nil, err
+	}
+	observeReassignments(models.AssignmentActionReassign, reassigned, true)
+	if isActive {
+		s.prService.NotifyStaffing()
+	}
+	return user, reassigned, nil
+}
+
+func (s *TeamService) DeactivateUsers(ctx context.Context, userIDs []string, teamName string) (models.DeactivationReport, error) {
+	if len(userIDs) == 0 && teamName == "" {
+		return models.DeactivationReport{}, models.ErrNothingToDeactivate
+	}
+	if teamName != "" {
+		if _, err := s.r.GetTeam(ctx, teamName); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return models.DeactivationReport{}, models.ErrTeamNotFound
+			}
+			return models.DeactivationReport{}, err
+		}
+	}
+
+	tx, err := s.r.DB.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return models.DeactivationReport{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	deactivated, err := s.r.DeactivateUsers(ctx, tx, userIDs, teamName)
+	if err != nil {
+		return models.DeactivationReport{}, err
+	}
+	if len(deactivated) == 0 {
+		return models.DeactivationReport{}, models.ErrUserNotFound
+	}
+	slices.Sort(deactivated)
+
+	pullRequests, err := s.prService.reassignReleasedReviews(ctx, tx, deactivated)
+	if err != nil {
+		return models.DeactivationReport{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.DeactivationReport{}, err
 	}
-	return user, nil
+	observeReassignments(models.AssignmentActionDeactivate, pullRequests, true)
+
+	return models.DeactivationReport{DeactivatedUsers: deactivated, PullRequests: pullRequests}, nil
 }
 
 func (s *TeamService) GetPRsByReviewer(ctx context.Context, reviewerID string) ([]models.PullRequestShort, error) {
@@ -75,3 +160,143 @@ func (s *TeamService) GetPRsByReviewer(ctx context.Context, reviewerID string) (
 	}
 	return pullRequests, nil
 }
+
+func (s *TeamService) GetTeamSettings(ctx context.Context, teamName string) (models.TeamSettings, error) {
+	if _, err := s.r.GetTeam(ctx, teamName); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.TeamSettings{}, models.ErrTeamNotFound
+		}
+		return models.TeamSettings{}, err
+	}
+	return s.r.GetTeamSettings(ctx, teamName)
+}
+
+func (s *TeamService) UpdateTeamSettings(ctx context.Context, teamName string, update func(*models.TeamSettings) error) (models.TeamSettings, error) {
+	tx, err := s.r.DB.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return models.TeamSettings{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	settings, err := s.r.LockTeamSettings(ctx, tx, teamName)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return models.TeamSettings{}, models.ErrTeamNotFound
+		}
+		return models.TeamSettings{}, err
+	}
+	if err = update(&settings); err != nil {
+		return models.TeamSettings{}, err
+	}
+	settings.TeamName = teamName
+
+	if settings.AssignmentStrategy == "" {
+		settings.AssignmentStrategy = models.StrategyLeastLoaded
+	}
+	if !slices.Contains(models.AssignmentStrategies, settings.AssignmentStrategy) {
+		return models.TeamSettings{}, models.ErrUnknownAssignmentStrategy
+	}
+	if settings.ReviewersCount == 0 {
+		settings.ReviewersCount = models.DefaultReviewersCount
+	}
+	if settings.ReviewersCount < 0 {
+		return models.TeamSettings{}, models.ErrInvalidReviewersCount
+	}
+	if settings.RequiredApprovals < 0 {
+		return models.TeamSettings{}, models.ErrInvalidRequiredApprovals
+	}
+	if settings.RequiredApprovals > settings.ReviewersCount {
+		return models.TeamSettings{}, models.ErrApprovalsExceedReviewers
+	}
+	if settings.MaxOpenReviews != nil && *settings.MaxOpenReviews <= 0 {
+		return models.TeamSettings{}, models.ErrInvalidCapacity
+	}
+	if settings.ReviewSLAHours != nil && *settings.ReviewSLAHours <= 0 {
+		return models.TeamSettings{}, models.ErrInvalidReviewSLA
+	}
+	if settings.SLAAction == "" {
+		settings.SLAAction = models.SLAActionReassign
+	}
+	if !slices.Contains(models.SLAActions, settings.SLAAction) {
+		return models.TeamSettings{}, models.ErrUnknownSLAAction
+	}
+	if (settings.SLAAction == models.SLAActionEscalate || settings.ReviewSLAHours != nil) && settings.TeamLead == nil {
+		return models.TeamSettings{}, models.ErrTeamLeadRequired
+	}
+	if slices.Contains(settings.FallbackTeams, settings.TeamName) {
+		return models.TeamSettings{}, models.ErrInvalidFallbackTeam
+	}
+	var fallbackTeams []string
+	for _, fallbackTeam := range settings.FallbackTeams {
+		if !slices.Contains(fallbackTeams, fallbackTeam) {
+			fallbackTeams = append(fallbackTeams, fallbackTeam)
+		}
+	}
+	settings.FallbackTeams = fallbackTeams
+
+	saved, err := s.r.UpsertTeamSettings(ctx, tx, settings)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "team_settings_team_lead_fkey" {
+			return models.TeamSettings{}, models.ErrUserNotFound
+		}
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return models.TeamSettings{}, models.ErrTeamNotFound
+		}
+		return models.TeamSettings{}, err
+	}
+
+	err = s.r.ReplaceFallbackTeams(ctx, tx, settings.TeamName, settings.FallbackTeams)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return models.TeamSettings{}, models.ErrTeamNotFound
+		}
+		return models.TeamSettings{}, err
+	}
+	saved.FallbackTeams = settings.FallbackTeams
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.TeamSettings{}, err
+	}
+	s.prService.NotifyStaffing()
+	return saved, nil
+}
+
+func (s *TeamService) GetCodeOwners(ctx context.Context, teamName string) (models.TeamCodeOwners, error) {
+	if _, err := s.r.GetTeam(ctx, teamName); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.TeamCodeOwners{}, models.ErrTeamNotFound
+		}
+		return models.TeamCodeOwners{}, err
+	}
+
+	content, err := s.r.GetCodeOwners(ctx, teamName)
+	if err != nil {
+		return models.TeamCodeOwners{}, err
+	}
+	matcher, err := parseCodeOwners(content)
+	if err != nil {
+		return models.TeamCodeOwners{}, err
+	}
+	return models.TeamCodeOwners{TeamName: teamName, Content: content, Rules: matcher.rules}, nil
+}
+
+func (s *TeamService) UploadCodeOwners(ctx context.Context, codeOwners models.TeamCodeOwners) (models.TeamCodeOwners, error) {
+	matcher, err := parseCodeOwners(codeOwners.Content)
+	if err != nil {
+		return models.TeamCodeOwners{}, err
+	}
+
+	err = s.r.UpsertCodeOwners(ctx, codeOwners.TeamName, codeOwners.Content)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return models.TeamCodeOwners{}, models.ErrTeamNotFound
+		}
+		return models.TeamCodeOwners{}, err
+	}
+	codeOwners.Rules = matcher.rules
+	return codeOwners, nil
+}
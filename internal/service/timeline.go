@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"pull-request-reviewers-service/internal/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, actorID string) context.Context {
+	if actorID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, actorKey{}, actorID)
+}
+
+func actorFrom(ctx context.Context) *string {
+	actorID, ok := ctx.Value(actorKey{}).(string)
+	if !ok {
+		return nil
+	}
+	return &actorID
+}
+
+func newEvent(prID, eventType, oldValue, newValue string) models.PullRequestEvent {
+	event := models.PullRequestEvent{PullRequestID: prID, Type: eventType}
+	if oldValue != "" {
+		event.OldValue = &oldValue
+	}
+	if newValue != "" {
+		event.NewValue = &newValue
+	}
+	return event
+}
+
+func reviewerEvents(prID, eventType string, reviewerIDs []string) []models.PullRequestEvent {
+	events := make([]models.PullRequestEvent, 0, len(reviewerIDs))
+	for _, reviewerID := range reviewerIDs {
+		if eventType == models.EventReviewerRemoved {
+			events = append(events, newEvent(prID, eventType, reviewerID, ""))
+		} else {
+			events = append(events, newEvent(prID, eventType, "", reviewerID))
+		}
+	}
+	return events
+}
+
+func (s *PullRequestService) recordEvents(ctx context.Context, tx pgx.Tx, events ...models.PullRequestEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	actor, now := actorFrom(ctx), time.Now()
+	for i := range events {
+		if events[i].Actor == nil {
+			events[i].Actor = actor
+		}
+		events[i].CreatedAt = now
+	}
+	return s.r.AddEvents(ctx, tx, events)
+}
+
+func (s *PullRequestService) GetTimeline(ctx context.Context, prID string) ([]models.PullRequestEvent, error) {
+	exist, err := s.r.PullRequestExists(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, models.ErrPullRequestNotFound
+	}
+	return s.r.GetEvents(ctx, prID)
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"cmp"
+	"fmt"
+	"pull-request-reviewers-service/internal/models"
+	"slices"
+	"time"
+)
+
+const (
+	defaultTimeZone  = "UTC"
+	defaultWorkStart = "09:00"
+	defaultWorkEnd   = "18:00"
+	clockLayout      = "15:04"
+)
+
+func withWorkingHours(member models.TeamMember) (models.TeamMember, error) {
+	if member.TimeZone == "" {
+		member.TimeZone = defaultTimeZone
+	}
+	if member.WorkStart == "" {
+		member.WorkStart = defaultWorkStart
+	}
+	if member.WorkEnd == "" {
+		member.WorkEnd = defaultWorkEnd
+	}
+
+	if _, err := time.LoadLocation(member.TimeZone); err != nil {
+		return models.TeamMember{}, fmt.Errorf("%w: unknown time zone %q", models.ErrInvalidWorkingHours, member.TimeZone)
+	}
+	for _, clock := range []string{member.WorkStart, member.WorkEnd} {
+		if _, err := time.Parse(clockLayout, clock); err != nil {
+			return models.TeamMember{}, fmt.Errorf("%w: bad time %q, expected HH:MM", models.ErrInvalidWorkingHours, clock)
+		}
+	}
+	return member, nil
+}
+
+type workingWindow struct {
+	location *time.Location
+	start    time.Duration
+	end      time.Duration
+}
+
+func candidateWindow(candidate models.ReviewerCandidate) (workingWindow, bool) {
+	location, err := time.LoadLocation(candidate.TimeZone)
+	if err != nil {
+		return workingWindow{}, false
+	}
+	start, err := time.Parse(clockLayout, candidate.WorkStart)
+	if err != nil {
+		return workingWindow{}, false
+	}
+	end, err := time.Parse(clockLayout, candidate.WorkEnd)
+	if err != nil {
+		return workingWindow{}, false
+	}
+	return workingWindow{
+		location: location,
+		start:    time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute,
+		end:      time.Duration(end.Hour())*time.Hour + time.Duration(end.Minute())*time.Minute,
+	}, true
+}
+
+func (w workingWindow) at(day time.Time, clock time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, w.location)
+}
+
+func dayOf(local time.Time) time.Time {
+	return time.Date(local.Year(), local.Month(), local.Day(), 12, 0, 0, 0, local.Location())
+}
+
+func clockOf(local time.Time) time.Duration {
+	return time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute + time.Duration(local.Second())*time.Second
+}
+
+func isWorkday(day time.Time) bool {
+	return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+}
+
+func (w workingWindow) contains(now time.Time) bool {
+	local := now.In(w.location)
+	clock := clockOf(local)
+
+	if w.start < w.end {
+		return isWorkday(local) && clock >= w.start && clock < w.end
+	}
+	if clock >= w.start {
+		return isWorkday(local)
+	}
+	return clock < w.end && isWorkday(dayOf(local).AddDate(0, 0, -1))
+}
+
+func (w workingWindow) nextStart(now time.Time) time.Time {
+	local := now.In(w.location)
+	for days := range 8 {
+		day := dayOf(local).AddDate(0, 0, days)
+		start := w.at(day, w.start)
+		if isWorkday(day) && start.After(now) {
+			return start
+		}
+	}
+	return now
+}
+
+func (w workingWindow) addWorkingTime(from time.Time, budget time.Duration) time.Time {
+	local := from.In(w.location)
+	day := dayOf(local).AddDate(0, 0, -1)
+	for range 3660 {
+		if isWorkday(day) {
+			start, end := w.at(day, w.start), w.at(day, w.end)
+			if w.end <= w.start {
+				end = w.at(day.AddDate(0, 0, 1), w.end)
+			}
+			if start.Before(from) {
+				start = from
+			}
+			if end.After(start) {
+				available := end.Sub(start)
+				if available >= budget {
+					return start.Add(budget)
+				}
+				budget -= available
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return from.Add(budget)
+}
+
+func workingHoursTiers(tiers []reviewerTier, now time.Time) []reviewerTier {
+	refined := make([]reviewerTier, 0, len(tiers)*2)
+	for _, tier := range tiers {
+		var working, offline []models.ReviewerCandidate
+		waits := make(map[string]time.Duration)
+		for _, candidate := range tier.candidates {
+			window, ok := candidateWindow(candidate)
+			if !ok || window.contains(now) {
+				working = append(working, candidate)
+				continue
+			}
+			offline = append(offline, candidate)
+			waits[candidate.UserID] = window.nextStart(now).Sub(now).Truncate(time.Hour)
+		}
+		refined = append(refined, tier.narrow(working, "in_working_hours"))
+
+		slices.SortStableFunc(offline, func(a, b models.ReviewerCandidate) int {
+			return cmp.Compare(waits[a.UserID], waits[b.UserID])
+		})
+		for start := 0; start < len(offline); {
+			end := start + 1
+			for end < len(offline) && waits[offline[end].UserID] == waits[offline[start].UserID] {
+				end++
+			}
+			reason := fmt.Sprintf("next_working_window_in_%s", waits[offline[start].UserID])
+			refined = append(refined, tier.narrow(offline[start:end], reason))
+			start = end
+		}
+	}
+	return refined
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"pull-request-reviewers-service/internal/models"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func mustWindow(t *testing.T, timeZone, start, end string) workingWindow {
+	t.Helper()
+	window, ok := candidateWindow(models.ReviewerCandidate{TimeZone: timeZone, WorkStart: start, WorkEnd: end})
+	if !ok {
+		t.Fatalf("candidateWindow(%s, %s, %s) failed", timeZone, start, end)
+	}
+	return window
+}
+
+func mustLocal(t *testing.T, timeZone, value string) time.Time {
+	t.Helper()
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		t.Fatalf("LoadLocation(%s): %v", timeZone, err)
+	}
+	local, err := time.ParseInLocation("2006-01-02 15:04", value, location)
+	if err != nil {
+		t.Fatalf("ParseInLocation(%s): %v", value, err)
+	}
+	return local
+}
+
+func TestWorkingWindowContains(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeZone string
+		start    string
+		end      string
+		now      string
+		want     bool
+	}{
+		{name: "inside day window", timeZone: "UTC", start: "09:00", end: "18:00", now: "2025-03-12 10:00", want: true},
+		{name: "window start inclusive", timeZone: "UTC", start: "09:00", end: "18:00", now: "2025-03-12 09:00", want: true},
+		{name: "window end exclusive", timeZone: "UTC", start: "09:00", end: "18:00", now: "2025-03-12 18:00", want: false},
+		{name: "saturday", timeZone: "UTC", start: "09:00", end: "18:00", now: "2025-03-15 10:00", want: false},
+		{name: "overnight evening", timeZone: "UTC", start: "22:00", end: "06:00", now: "2025-03-12 23:00", want: true},
+		{name: "overnight morning", timeZone: "UTC", start: "22:00", end: "06:00", now: "2025-03-13 05:00", want: true},
+		{name: "overnight gap", timeZone: "UTC", start: "22:00", end: "06:00", now: "2025-03-13 12:00", want: false},
+		{name: "overnight from friday into saturday", timeZone: "UTC", start: "22:00", end: "06:00", now: "2025-03-15 02:00", want: true},
+		{name: "overnight saturday evening", timeZone: "UTC", start: "22:00", end: "06:00", now: "2025-03-15 23:00", want: false},
+		{name: "overnight from sunday into monday", timeZone: "UTC", start: "22:00", end: "06:00", now: "2025-03-17 02:00", want: false},
+		{name: "other time zone", timeZone: "Asia/Tokyo", start: "09:00", end: "18:00", now: "2025-03-12 17:30", want: true},
+		{name: "workday starting with dst gap", timeZone: "Africa/Cairo", start: "09:00", end: "18:00", now: "2024-04-26 09:30", want: true},
+		{name: "dst gap day end", timeZone: "Africa/Cairo", start: "09:00", end: "18:00", now: "2024-04-26 18:00", want: false},
+		{name: "workday starting with repeated hour", timeZone: "Africa/Cairo", start: "09:00", end: "18:00", now: "2024-11-01 08:30", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := mustWindow(t, tt.timeZone, tt.start, tt.end)
+			if got := window.contains(mustLocal(t, tt.timeZone, tt.now)); got != tt.want {
+				t.Errorf("contains(%s) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkingWindowNextStart(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeZone string
+		start    string
+		end      string
+		now      string
+		want     string
+	}{
+		{name: "later today", timeZone: "UTC", start: "09:00", end: "18:00", now: "2025-03-12 07:00", want: "2025-03-12 09:00"},
+		{name: "tomorrow", timeZone: "UTC", start: "09:00", end: "18:00", now: "2025-03-12 19:00", want: "2025-03-13 09:00"},
+		{name: "over weekend", timeZone: "UTC", start: "09:00", end: "18:00", now: "2025-03-14 19:00", want: "2025-03-17 09:00"},
+		{name: "overnight window", timeZone: "UTC", start: "22:00", end: "06:00", now: "2025-03-12 12:00", want: "2025-03-12 22:00"},
+		{name: "across spring dst", timeZone: "Europe/Berlin", start: "09:00", end: "18:00", now: "2025-03-28 19:00", want: "2025-03-31 09:00"},
+		{name: "day with dst gap", timeZone: "Africa/Cairo", start: "09:00", end: "18:00", now: "2024-04-25 19:00", want: "2024-04-26 09:00"},
+		{name: "day with repeated hour", timeZone: "Africa/Cairo", start: "09:00", end: "18:00", now: "2024-10-31 19:00", want: "2024-11-01 09:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := mustWindow(t, tt.timeZone, tt.start, tt.end)
+			got := window.nextStart(mustLocal(t, tt.timeZone, tt.now))
+			if want := mustLocal(t, tt.timeZone, tt.want); !got.Equal(want) {
+				t.Errorf("nextStart(%s) = %s, want %s", tt.now, got, want)
+			}
+		})
+	}
+}
+
+func TestWorkingWindowAddWorkingTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeZone string
+		start    string
+		end      string
+		from     string
+		budget   time.Duration
+		want     string
+	}{
+		{name: "within one day", timeZone: "UTC", start: "09:00", end: "18:00", from: "2025-03-12 10:00", budget: 4 * time.Hour, want: "2025-03-12 14:00"},
+		{name: "ends exactly at window end", timeZone: "UTC", start: "09:00", end: "18:00", from: "2025-03-12 10:00", budget: 8 * time.Hour, want: "2025-03-12 18:00"},
+		{name: "rolls to next day", timeZone: "UTC", start: "09:00", end: "18:00", from: "2025-03-12 16:00", budget: 4 * time.Hour, want: "2025-03-13 11:00"},
+		{name: "starts before window", timeZone: "UTC", start: "09:00", end: "18:00", from: "2025-03-12 06:00", budget: 2 * time.Hour, want: "2025-03-12 11:00"},
+		{name: "skips weekend", timeZone: "UTC", start: "09:00", end: "18:00", from: "2025-03-14 17:00", budget: 3 * time.Hour, want: "2025-03-17 11:00"},
+		{name: "assigned on weekend", timeZone: "UTC", start: "09:00", end: "18:00", from: "2025-03-15 12:00", budget: time.Hour, want: "2025-03-17 10:00"},
+		{name: "overnight window", timeZone: "UTC", start: "22:00", end: "06:00", from: "2025-03-12 23:00", budget: 4 * time.Hour, want: "2025-03-13 03:00"},
+		{name: "overnight window after midnight", timeZone: "UTC", start: "22:00", end: "06:00", from: "2025-03-13 02:00", budget: 6 * time.Hour, want: "2025-03-14 00:00"},
+		{name: "across spring dst", timeZone: "Europe/Berlin", start: "09:00", end: "18:00", from: "2025-03-28 17:00", budget: 2 * time.Hour, want: "2025-03-31 10:00"},
+		{name: "day with dst gap", timeZone: "Africa/Cairo", start: "09:00", end: "18:00", from: "2024-04-25 17:00", budget: 2 * time.Hour, want: "2024-04-26 10:00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := mustWindow(t, tt.timeZone, tt.start, tt.end)
+			got := window.addWorkingTime(mustLocal(t, tt.timeZone, tt.from), tt.budget)
+			if want := mustLocal(t, tt.timeZone, tt.want); !got.Equal(want) {
+				t.Errorf("addWorkingTime(%s, %s) = %s, want %s", tt.from, tt.budget, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS team_settings (
+                              team_name TEXT PRIMARY KEY REFERENCES teams(team_name),
+                              assignment_strategy TEXT NOT NULL DEFAULT 'least_loaded'
+                                  CHECK (assignment_strategy IN ('random', 'round_robin', 'least_loaded', 'weighted'))
+);
@@ -0,0 +1,2 @@
+ALTER TABLE team_settings
+    ADD COLUMN IF NOT EXISTS reviewers_count INT NOT NULL DEFAULT 2 CHECK (reviewers_count > 0);
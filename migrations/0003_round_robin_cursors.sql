@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS round_robin_cursors (
+                                    team_name TEXT PRIMARY KEY REFERENCES teams(team_name),
+                                    last_user_id TEXT NOT NULL DEFAULT ''
+);
@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS team_codeowners (
+                                team_name TEXT PRIMARY KEY REFERENCES teams(team_name),
+                                content TEXT NOT NULL
+);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS user_absences (
+                              absence_id BIGSERIAL PRIMARY KEY,
+                              user_id TEXT NOT NULL REFERENCES users(user_id),
+                              starts_at TIMESTAMPTZ NOT NULL,
+                              ends_at TIMESTAMPTZ NOT NULL,
+                              reason TEXT NOT NULL DEFAULT '',
+                              auto_reassign BOOLEAN NOT NULL DEFAULT FALSE,
+                              reassigned BOOLEAN NOT NULL DEFAULT FALSE,
+                              CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS user_absences_user_period_idx ON user_absences (user_id, starts_at, ends_at);
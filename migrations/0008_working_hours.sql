@@ -0,0 +1,4 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS time_zone TEXT NOT NULL DEFAULT 'UTC',
+    ADD COLUMN IF NOT EXISTS work_start TIME NOT NULL DEFAULT '09:00',
+    ADD COLUMN IF NOT EXISTS work_end TIME NOT NULL DEFAULT '18:00';
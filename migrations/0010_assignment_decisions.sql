@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS assignment_decisions (
+                                     decision_id BIGSERIAL PRIMARY KEY,
+                                     pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+                                     action TEXT NOT NULL CHECK (action IN ('CREATE', 'REASSIGN', 'FILL')),
+                                     strategy TEXT NOT NULL,
+                                     replaced_reviewer TEXT,
+                                     candidates JSONB NOT NULL,
+                                     excluded JSONB NOT NULL,
+                                     selected JSONB NOT NULL,
+                                     decided_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS assignment_decisions_pr_idx ON assignment_decisions (pull_request_id, decided_at);
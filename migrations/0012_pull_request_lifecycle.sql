@@ -0,0 +1,9 @@
+ALTER TABLE pull_requests DROP CONSTRAINT IF EXISTS pull_requests_status_check;
+ALTER TABLE pull_requests
+    ADD CONSTRAINT pull_requests_status_check CHECK (status IN ('DRAFT', 'OPEN', 'MERGED', 'CLOSED')),
+    ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS changed_files TEXT[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE assignment_decisions DROP CONSTRAINT IF EXISTS assignment_decisions_action_check;
+ALTER TABLE assignment_decisions
+    ADD CONSTRAINT assignment_decisions_action_check CHECK (action IN ('CREATE', 'REASSIGN', 'FILL', 'READY', 'REOPEN'));
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS review_declines (
+                                 decline_id BIGSERIAL PRIMARY KEY,
+                                 pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+                                 reviewer_id TEXT NOT NULL REFERENCES users(user_id),
+                                 reason TEXT NOT NULL CHECK (reason IN ('CONFLICT_OF_INTEREST', 'NO_EXPERTISE', 'OVERLOADED')),
+                                 replaced_by TEXT NOT NULL REFERENCES users(user_id),
+                                 declined_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS review_declines_reviewer_idx ON review_declines (reviewer_id);
+
+ALTER TABLE assignment_decisions DROP CONSTRAINT IF EXISTS assignment_decisions_action_check;
+ALTER TABLE assignment_decisions
+    ADD CONSTRAINT assignment_decisions_action_check
+        CHECK (action IN ('CREATE', 'REASSIGN', 'FILL', 'READY', 'REOPEN', 'DECLINE'));
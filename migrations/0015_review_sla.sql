@@ -0,0 +1,20 @@
+ALTER TABLE team_settings
+    ADD COLUMN IF NOT EXISTS review_sla_hours INT CHECK (review_sla_hours > 0),
+    ADD COLUMN IF NOT EXISTS sla_action TEXT NOT NULL DEFAULT 'reassign' CHECK (sla_action IN ('reassign', 'escalate')),
+    ADD COLUMN IF NOT EXISTS team_lead TEXT REFERENCES users(user_id);
+
+CREATE TABLE IF NOT EXISTS review_escalations (
+                                    escalation_id BIGSERIAL PRIMARY KEY,
+                                    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+                                    reviewer_id TEXT NOT NULL REFERENCES users(user_id),
+                                    assigned_at TIMESTAMPTZ NOT NULL,
+                                    action TEXT NOT NULL CHECK (action IN ('reassign', 'escalate')),
+                                    escalated_to TEXT REFERENCES users(user_id),
+                                    escalated_at TIMESTAMPTZ NOT NULL,
+                                    UNIQUE (pull_request_id, reviewer_id, assigned_at)
+);
+
+ALTER TABLE assignment_decisions DROP CONSTRAINT IF EXISTS assignment_decisions_action_check;
+ALTER TABLE assignment_decisions
+    ADD CONSTRAINT assignment_decisions_action_check
+        CHECK (action IN ('CREATE', 'REASSIGN', 'FILL', 'READY', 'REOPEN', 'DECLINE', 'SLA'));
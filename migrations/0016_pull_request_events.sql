@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS pull_request_events (
+                                     event_id BIGSERIAL PRIMARY KEY,
+                                     pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+                                     event_type TEXT NOT NULL CHECK (event_type IN ('CREATED', 'STATUS_CHANGED', 'REVIEWER_ASSIGNED',
+                                                                                    'REVIEWER_REPLACED', 'REVIEWER_REMOVED', 'REVIEW_SUBMITTED')),
+                                     actor TEXT,
+                                     old_value TEXT,
+                                     new_value TEXT,
+                                     created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS pull_request_events_pr_idx ON pull_request_events (pull_request_id, event_id);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS review_assignments (
+                                    assignment_id BIGSERIAL PRIMARY KEY,
+                                    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+                                    reviewer_id TEXT NOT NULL REFERENCES users(user_id),
+                                    assigned_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+                                    unassigned_at TIMESTAMPTZ,
+                                    unassign_reason TEXT CHECK (unassign_reason IN ('REASSIGN', 'DECLINE', 'SLA', 'DEACTIVATE', 'CLOSE'))
+);
+
+CREATE INDEX IF NOT EXISTS review_assignments_reviewer_idx ON review_assignments (reviewer_id);
+CREATE INDEX IF NOT EXISTS review_assignments_pr_idx ON review_assignments (pull_request_id) WHERE unassigned_at IS NULL;
+
+INSERT INTO review_assignments (pull_request_id, reviewer_id, assigned_at)
+SELECT r.pull_request_id, r.reviewer_id, r.assigned_at
+FROM reviewers r
+WHERE NOT EXISTS (
+    SELECT 1 FROM review_assignments ra
+    WHERE ra.pull_request_id = r.pull_request_id AND ra.reviewer_id = r.reviewer_id
+);
@@ -0,0 +1,9 @@
+ALTER TABLE review_assignments ADD COLUMN IF NOT EXISTS first_reviewed_at TIMESTAMPTZ;
+
+UPDATE review_assignments ra
+SET first_reviewed_at = r.reviewed_at
+FROM reviewers r
+WHERE ra.pull_request_id = r.pull_request_id
+  AND ra.reviewer_id = r.reviewer_id
+  AND ra.unassigned_at IS NULL
+  AND ra.first_reviewed_at IS NULL;
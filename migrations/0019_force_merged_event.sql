@@ -0,0 +1,5 @@
+ALTER TABLE pull_request_events DROP CONSTRAINT IF EXISTS pull_request_events_event_type_check;
+ALTER TABLE pull_request_events
+    ADD CONSTRAINT pull_request_events_event_type_check
+        CHECK (event_type IN ('CREATED', 'STATUS_CHANGED', 'REVIEWER_ASSIGNED',
+                              'REVIEWER_REPLACED', 'REVIEWER_REMOVED', 'REVIEW_SUBMITTED', 'FORCE_MERGED'));
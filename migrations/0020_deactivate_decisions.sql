@@ -0,0 +1,4 @@
+ALTER TABLE assignment_decisions DROP CONSTRAINT IF EXISTS assignment_decisions_action_check;
+ALTER TABLE assignment_decisions
+    ADD CONSTRAINT assignment_decisions_action_check
+        CHECK (action IN ('CREATE', 'REASSIGN', 'FILL', 'READY', 'REOPEN', 'DECLINE', 'SLA', 'DEACTIVATE'));
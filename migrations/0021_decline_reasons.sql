@@ -0,0 +1,13 @@
+ALTER TABLE review_assignments
+    ADD COLUMN IF NOT EXISTS decline_reason TEXT
+        CHECK (decline_reason IN ('CONFLICT_OF_INTEREST', 'NO_EXPERTISE', 'OVERLOADED'));
+
+UPDATE review_assignments ra
+SET decline_reason = d.reason
+FROM review_declines d
+WHERE ra.pull_request_id = d.pull_request_id
+  AND ra.reviewer_id = d.reviewer_id
+  AND ra.unassign_reason = 'DECLINE'
+  AND ra.decline_reason IS NULL;
+
+DROP TABLE IF EXISTS review_declines;
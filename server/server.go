@@ -7,8 +7,11 @@ import (
 	"net/http"
 	"os"
 	"pull-request-reviewers-service/internal/api"
+	"pull-request-reviewers-service/internal/metrics"
 	"pull-request-reviewers-service/internal/repository"
 	"pull-request-reviewers-service/internal/service"
+	"pull-request-reviewers-service/migrations"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -36,27 +39,66 @@ func (s *Server) Init() {
 	}
 	log.Println("Success connest to PostgreSQL")
 
+	if err = migrations.Apply(context.Background(), pool); err != nil {
+		log.Fatalf("postgres migrations error: %v", err)
+	}
+
 	s.DB = pool
 }
 
 func (s *Server) Start() {
 	teamRepo := repository.NewTeamRepository(s.DB)
-	teamService := service.NewTeamService(teamRepo)
-	teamHandler := api.NewTeamHandler(teamService)
-
 	prRepo := repository.NewPullRequestRepository(s.DB)
 	prService := service.NewPullRequestService(prRepo, teamRepo)
 	prHandler := api.NewPullRequestHandler(prService)
+	go prService.RunStaffing(context.Background(), 30*time.Second)
+	go prService.RunReviewSLA(context.Background(), time.Minute)
+
+	teamService := service.NewTeamService(teamRepo, prService)
+	teamHandler := api.NewTeamHandler(teamService)
+
+	absenceRepo := repository.NewAbsenceRepository(s.DB)
+	absenceService := service.NewAbsenceService(absenceRepo, prRepo, prService)
+	absenceHandler := api.NewAbsenceHandler(absenceService)
+	go absenceService.RunAutoReassign(context.Background(), time.Minute)
 
 	r := chi.NewRouter()
+	r.Use(metrics.Middleware)
+	r.Use(api.ActorMiddleware)
+	r.Method(http.MethodGet, "/metrics", metrics.Handler(
+		metrics.NewPoolCollector(s.DB),
+		metrics.NewCountCollector("pull_requests", "Pull requests by status.", "status", prService.CountPullRequestsByStatus),
+		metrics.NewCountCollector("team_active_members", "Active members per team.", "team", prService.CountActiveMembersByTeam),
+	))
 	r.Post("/team/add", teamHandler.CreateTeam)
 	r.Get("/team/get", teamHandler.GetTeam)
+	r.Get("/team/settings", teamHandler.GetTeamSettings)
+	r.Post("/team/settings", teamHandler.UpdateTeamSettings)
+	r.Get("/team/codeowners", teamHandler.GetCodeOwners)
+	r.Post("/team/codeowners", teamHandler.UploadCodeOwners)
 	r.Post("/users/setIsActive", teamHandler.SetIsActiveUser)
+	r.Post("/users/deactivate", teamHandler.DeactivateUsers)
 	r.Post("/pullRequest/create", prHandler.CreatePullRequest)
+	r.Post("/pullRequest/previewAssignment", prHandler.PreviewAssignment)
 	r.Post("/pullRequest/merge", prHandler.MergePullRequest)
+	r.Post("/pullRequest/ready", prHandler.MarkReady)
+	r.Post("/pullRequest/close", prHandler.ClosePullRequest)
+	r.Post("/pullRequest/reopen", prHandler.ReopenPullRequest)
 	r.Post("/pullRequest/reassign", prHandler.ReassignReviewer)
+	r.Post("/pullRequest/review", prHandler.SubmitReview)
+	r.Post("/pullRequest/decline", prHandler.DeclineReview)
+	r.Get("/pullRequest/understaffed", prHandler.GetUnderstaffedPullRequests)
+	r.Get("/pullRequest/assignmentLog", prHandler.GetAssignmentLog)
+	r.Get("/pullRequest/overdue", prHandler.GetOverdueReviews)
+	r.Get("/pullRequest/timeline", prHandler.GetTimeline)
 	r.Get("/users/getReview", teamHandler.GetPRsByReviewer)
 	r.Get("/stats/reviewers", prHandler.GetAssignStat)
+	r.Get("/stats/metrics", prHandler.GetReviewMetrics)
+	r.Get("/stats/fairness", prHandler.GetFairnessReport)
+	r.Get("/stats/pairings", prHandler.GetPairingMatrix)
+	r.Post("/users/absence/add", absenceHandler.CreateAbsence)
+	r.Get("/users/absence/get", absenceHandler.GetAbsences)
+	r.Post("/users/absence/delete", absenceHandler.DeleteAbsence)
 
 	err := http.ListenAndServe(":8080", r)
 	if err != nil {